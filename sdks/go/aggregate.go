@@ -0,0 +1,57 @@
+package plugport
+
+import (
+	"context"
+	"fmt"
+)
+
+// AggregateOptions contains options for the Aggregate operation.
+type AggregateOptions struct {
+	// AllowDiskUse enables writing to temporary files during aggregation.
+	AllowDiskUse *bool
+	// BatchSize sets the number of documents to return per batch.
+	BatchSize int32
+	// MaxTimeMS sets the maximum amount of time to allow the operation to run, in milliseconds.
+	MaxTimeMS int64
+	// Collation specifies the collation to use for string comparisons.
+	Collation map[string]interface{}
+}
+
+// Aggregate runs an aggregation pipeline against the collection and returns a
+// cursor over the result set. Pipelines are typically built with the
+// plugport/pipeline package, e.g. pipeline.New().Match(...).Group(...).Stages().
+func (c *Collection) Aggregate(ctx context.Context, pipeline []interface{}, opts ...AggregateOptions) (*Cursor, error) {
+	body := map[string]interface{}{
+		"pipeline": pipeline,
+	}
+	if len(opts) > 0 {
+		opt := opts[0]
+		if opt.AllowDiskUse != nil {
+			body["allowDiskUse"] = *opt.AllowDiskUse
+		}
+		if opt.BatchSize > 0 {
+			body["batchSize"] = opt.BatchSize
+		}
+		if opt.MaxTimeMS > 0 {
+			body["maxTimeMS"] = opt.MaxTimeMS
+		}
+		if opt.Collation != nil {
+			body["collation"] = opt.Collation
+		}
+	}
+	c.applyReadConcern(body)
+
+	result, err := c.db.client.doRetryableRead(ctx, fmt.Sprintf("/api/v1/collections/%s/aggregate", c.name), body, c.readPreferenceHeaders())
+	if err != nil {
+		return nil, err
+	}
+
+	cur, err := newCursor(c, result["cursor"])
+	if err != nil {
+		return nil, err
+	}
+	if len(opts) > 0 {
+		cur.batchSize = opts[0].BatchSize
+	}
+	return cur, nil
+}
@@ -0,0 +1,89 @@
+package plugport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAggregateSendsPipelineAndOptions(t *testing.T) {
+	var gotBody map[string]interface{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		json.Unmarshal(data, &gotBody)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"cursor": map[string]interface{}{
+				"firstBatch": []interface{}{},
+				"id":         float64(0),
+			},
+		})
+	}))
+	defer srv.Close()
+
+	client := &Client{baseURL: srv.URL, codec: CodecJSON, httpClient: srv.Client()}
+	coll := client.Database("testdb").Collection("testcoll")
+
+	allowDiskUse := true
+	pipeline := []interface{}{
+		map[string]interface{}{"$match": map[string]interface{}{"active": true}},
+	}
+
+	cur, err := coll.Aggregate(context.Background(), pipeline, AggregateOptions{
+		AllowDiskUse: &allowDiskUse,
+		BatchSize:    50,
+	})
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if cur.batchSize != 50 {
+		t.Fatalf("expected cursor batchSize 50, got %d", cur.batchSize)
+	}
+
+	if gotBody["allowDiskUse"] != true {
+		t.Fatalf("got %+v", gotBody)
+	}
+	if gotBody["batchSize"] != float64(50) {
+		t.Fatalf("got %+v", gotBody)
+	}
+	if _, ok := gotBody["pipeline"].([]interface{}); !ok {
+		t.Fatalf("expected pipeline in body, got %+v", gotBody)
+	}
+}
+
+func TestAggregateRetriesOnRetryableReadError(t *testing.T) {
+	var attempts int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"errmsg":      "no primary available",
+				"code":        float64(10107),
+				"errorLabels": []interface{}{"RetryableReadError"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"cursor": map[string]interface{}{
+				"firstBatch": []interface{}{},
+				"id":         float64(0),
+			},
+		})
+	}))
+	defer srv.Close()
+
+	client := &Client{baseURL: srv.URL, codec: CodecJSON, httpClient: srv.Client(), retryReads: true}
+	coll := client.Database("testdb").Collection("testcoll")
+
+	if _, err := coll.Aggregate(context.Background(), []interface{}{}); err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+}
@@ -0,0 +1,38 @@
+package bson
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// String renders d as its two 64-bit halves in hex. This package does not
+// implement full IEEE 754-2008 decimal decoding, so String does not produce
+// the human-readable decimal text MongoDB itself would print; it exists so
+// Decimal128 values round-trip losslessly through Decimal128FromString and
+// through extended JSON's $numberDecimal.
+func (d Decimal128) String() string {
+	return fmt.Sprintf("%016x%016x", d.hi, d.lo)
+}
+
+// Decimal128FromString parses the hex form produced by Decimal128.String.
+func Decimal128FromString(s string) (Decimal128, error) {
+	if len(s) != 32 {
+		return Decimal128{}, fmt.Errorf("bson: invalid Decimal128 string %q", s)
+	}
+	hiBytes, err := hex.DecodeString(s[:16])
+	if err != nil {
+		return Decimal128{}, fmt.Errorf("bson: invalid Decimal128 string %q: %w", s, err)
+	}
+	loBytes, err := hex.DecodeString(s[16:])
+	if err != nil {
+		return Decimal128{}, fmt.Errorf("bson: invalid Decimal128 string %q: %w", s, err)
+	}
+	var hi, lo uint64
+	for _, b := range hiBytes {
+		hi = hi<<8 | uint64(b)
+	}
+	for _, b := range loBytes {
+		lo = lo<<8 | uint64(b)
+	}
+	return Decimal128{hi: hi, lo: lo}, nil
+}
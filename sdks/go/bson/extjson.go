@@ -0,0 +1,284 @@
+package bson
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// MarshalExtJSON encodes v as MongoDB canonical extended JSON: every
+// BSON-specific type, including plain numbers, is rendered as a tagged
+// object (e.g. {"$numberLong": "5"}, {"$oid": "..."}) so the encoding is
+// unambiguous and round-trips exactly through UnmarshalExtJSON. This is
+// stricter than plain JSON, which renders numbers and strings natively and
+// only tags the types JSON itself has no representation for.
+func MarshalExtJSON(v interface{}) ([]byte, error) {
+	doc, err := toD(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(docToExtJSON(doc))
+}
+
+// UnmarshalExtJSON decodes MongoDB extended JSON produced by MarshalExtJSON
+// (or by a server speaking canonical or relaxed extended JSON) into v, which
+// must be a pointer to a map[string]interface{}, a D, or a struct. A D
+// receives the top-level keys sorted by name, since decoding through
+// encoding/json does not keep the order of an object's keys.
+func UnmarshalExtJSON(data []byte, v interface{}) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	parsed, err := parseExtJSONValue(raw)
+	if err != nil {
+		return err
+	}
+	m, ok := parsed.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("bson: extended JSON document must be an object, got %T", parsed)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("bson: UnmarshalExtJSON requires a non-nil pointer, got %T", v)
+	}
+	if d, ok := v.(*D); ok {
+		*d = mapToD(m)
+		return nil
+	}
+	return assignDocument(m, rv.Elem())
+}
+
+func docToExtJSON(doc D) map[string]interface{} {
+	m := make(map[string]interface{}, len(doc))
+	for _, e := range doc {
+		m[e.Key] = valueToExtJSON(e.Value)
+	}
+	return m
+}
+
+func valueToExtJSON(v interface{}) interface{} {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case bool, string:
+		return val
+	case int:
+		return map[string]interface{}{"$numberLong": strconv.FormatInt(int64(val), 10)}
+	case int32:
+		return map[string]interface{}{"$numberInt": strconv.FormatInt(int64(val), 10)}
+	case int64:
+		return map[string]interface{}{"$numberLong": strconv.FormatInt(val, 10)}
+	case float32:
+		return map[string]interface{}{"$numberDouble": formatExtDouble(float64(val))}
+	case float64:
+		return map[string]interface{}{"$numberDouble": formatExtDouble(val)}
+	case time.Time:
+		return valueToExtJSON(NewDateTimeFromTime(val))
+	case DateTime:
+		return map[string]interface{}{"$date": map[string]interface{}{"$numberLong": strconv.FormatInt(int64(val), 10)}}
+	case ObjectID:
+		return map[string]interface{}{"$oid": val.Hex()}
+	case Decimal128:
+		return map[string]interface{}{"$numberDecimal": val.String()}
+	case Binary:
+		return map[string]interface{}{"$binary": map[string]interface{}{
+			"base64":  base64.StdEncoding.EncodeToString(val.Data),
+			"subType": fmt.Sprintf("%02x", val.Subtype),
+		}}
+	case RegEx:
+		return map[string]interface{}{"$regularExpression": map[string]interface{}{
+			"pattern": val.Pattern,
+			"options": val.Options,
+		}}
+	case D:
+		return docToExtJSON(val)
+	case M:
+		return docToExtJSON(mapToD(val))
+	case A:
+		arr := make([]interface{}, len(val))
+		for i, e := range val {
+			arr[i] = valueToExtJSON(e)
+		}
+		return arr
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map:
+		doc, err := toD(v)
+		if err != nil {
+			return nil
+		}
+		return docToExtJSON(doc)
+	case reflect.Struct:
+		doc, err := structToD(rv)
+		if err != nil {
+			return nil
+		}
+		return docToExtJSON(doc)
+	case reflect.Slice, reflect.Array:
+		arr := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			arr[i] = valueToExtJSON(rv.Index(i).Interface())
+		}
+		return arr
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil
+		}
+		return valueToExtJSON(rv.Elem().Interface())
+	}
+	return v
+}
+
+func formatExtDouble(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return strconv.FormatFloat(f, 'G', -1, 64)
+}
+
+// parseExtJSONValue walks a generic JSON tree (as produced by
+// encoding/json's default decoding into interface{}) and resolves any
+// extended JSON tagged objects into their native BSON-package types.
+func parseExtJSONValue(raw interface{}) (interface{}, error) {
+	switch val := raw.(type) {
+	case map[string]interface{}:
+		if len(val) == 1 {
+			for k, inner := range val {
+				switch k {
+				case "$oid":
+					s, _ := inner.(string)
+					return ObjectIDFromHex(s)
+				case "$numberInt":
+					s, _ := inner.(string)
+					n, err := strconv.ParseInt(s, 10, 32)
+					if err != nil {
+						return nil, fmt.Errorf("bson: invalid $numberInt %q: %w", s, err)
+					}
+					return int32(n), nil
+				case "$numberLong":
+					s, _ := inner.(string)
+					n, err := strconv.ParseInt(s, 10, 64)
+					if err != nil {
+						return nil, fmt.Errorf("bson: invalid $numberLong %q: %w", s, err)
+					}
+					return n, nil
+				case "$numberDouble":
+					return parseExtJSONDouble(inner)
+				case "$numberDecimal":
+					s, _ := inner.(string)
+					return Decimal128FromString(s)
+				case "$date":
+					return parseExtJSONDate(inner)
+				case "$binary":
+					return parseExtJSONBinary(inner)
+				case "$regularExpression":
+					return parseExtJSONRegex(inner)
+				}
+			}
+		}
+		m := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			pv, err := parseExtJSONValue(v)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = pv
+		}
+		return m, nil
+	case []interface{}:
+		arr := make([]interface{}, len(val))
+		for i, e := range val {
+			pv, err := parseExtJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = pv
+		}
+		return arr, nil
+	default:
+		return raw, nil
+	}
+}
+
+func parseExtJSONDouble(inner interface{}) (interface{}, error) {
+	s, ok := inner.(string)
+	if !ok {
+		if f, ok := inner.(float64); ok {
+			return f, nil
+		}
+		return nil, fmt.Errorf("bson: invalid $numberDouble value %#v", inner)
+	}
+	switch s {
+	case "NaN":
+		return math.NaN(), nil
+	case "Infinity":
+		return math.Inf(1), nil
+	case "-Infinity":
+		return math.Inf(-1), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("bson: invalid $numberDouble %q: %w", s, err)
+	}
+	return f, nil
+}
+
+func parseExtJSONDate(inner interface{}) (interface{}, error) {
+	if s, ok := inner.(string); ok {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, fmt.Errorf("bson: invalid $date %q: %w", s, err)
+		}
+		return NewDateTimeFromTime(t), nil
+	}
+	pv, err := parseExtJSONValue(inner)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := pv.(int64)
+	if !ok {
+		return nil, fmt.Errorf("bson: invalid $date value %#v", inner)
+	}
+	return DateTime(n), nil
+}
+
+func parseExtJSONBinary(inner interface{}) (interface{}, error) {
+	m, ok := inner.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("bson: invalid $binary value %#v", inner)
+	}
+	b64, _ := m["base64"].(string)
+	data, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return nil, fmt.Errorf("bson: invalid $binary base64: %w", err)
+	}
+	subTypeHex, _ := m["subType"].(string)
+	subType, err := strconv.ParseUint(subTypeHex, 16, 8)
+	if err != nil {
+		return nil, fmt.Errorf("bson: invalid $binary subType %q: %w", subTypeHex, err)
+	}
+	return Binary{Subtype: byte(subType), Data: data}, nil
+}
+
+func parseExtJSONRegex(inner interface{}) (interface{}, error) {
+	m, ok := inner.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("bson: invalid $regularExpression value %#v", inner)
+	}
+	pattern, _ := m["pattern"].(string)
+	options, _ := m["options"].(string)
+	return RegEx{Pattern: pattern, Options: options}, nil
+}
@@ -0,0 +1,48 @@
+package bson
+
+import "testing"
+
+func TestMarshalUnmarshalExtJSON(t *testing.T) {
+	id := NewObjectID()
+	in := M{
+		"_id":    id,
+		"name":   "Alice",
+		"age":    int32(30),
+		"amount": NewDecimal128(1, 2),
+	}
+
+	data, err := MarshalExtJSON(in)
+	if err != nil {
+		t.Fatalf("MarshalExtJSON: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := UnmarshalExtJSON(data, &out); err != nil {
+		t.Fatalf("UnmarshalExtJSON: %v", err)
+	}
+
+	if out["_id"] != id {
+		t.Fatalf("got _id %v, want %v", out["_id"], id)
+	}
+	if out["name"] != "Alice" {
+		t.Fatalf("got name %v", out["name"])
+	}
+	if out["age"] != int32(30) {
+		t.Fatalf("got age %v (%T)", out["age"], out["age"])
+	}
+	if out["amount"] != (NewDecimal128(1, 2)) {
+		t.Fatalf("got amount %v", out["amount"])
+	}
+}
+
+func TestMarshalExtJSONTagsNumbers(t *testing.T) {
+	data, err := MarshalExtJSON(M{"n": int64(5)})
+	if err != nil {
+		t.Fatalf("MarshalExtJSON: %v", err)
+	}
+
+	const want = `{"n":{"$numberLong":"5"}}`
+	if string(data) != want {
+		t.Fatalf("got %s, want %s", data, want)
+	}
+}
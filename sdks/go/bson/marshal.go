@@ -0,0 +1,319 @@
+package bson
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Element type tags, as defined by the BSON spec.
+const (
+	typeDouble     byte = 0x01
+	typeString     byte = 0x02
+	typeDocument   byte = 0x03
+	typeArray      byte = 0x04
+	typeBinary     byte = 0x05
+	typeObjectID   byte = 0x07
+	typeBoolean    byte = 0x08
+	typeDateTime   byte = 0x09
+	typeNull       byte = 0x0A
+	typeRegex      byte = 0x0B
+	typeInt32      byte = 0x10
+	typeInt64      byte = 0x12
+	typeDecimal128 byte = 0x13
+)
+
+// Marshal encodes v as a BSON document. v may be a D, M, map[string]interface{},
+// or a struct (whose exported fields are encoded in declaration order,
+// honoring `bson:"name,omitempty"` tags).
+func Marshal(v interface{}) ([]byte, error) {
+	doc, err := toD(v)
+	if err != nil {
+		return nil, err
+	}
+	return marshalDocument(doc)
+}
+
+func marshalDocument(doc D) ([]byte, error) {
+	var body []byte
+	for _, e := range doc {
+		elem, err := marshalElement(e.Key, e.Value)
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, elem...)
+	}
+
+	length := int32(len(body) + 5)
+	out := make([]byte, 4, length)
+	binary.LittleEndian.PutUint32(out, uint32(length))
+	out = append(out, body...)
+	out = append(out, 0x00)
+	return out, nil
+}
+
+func marshalElement(key string, value interface{}) ([]byte, error) {
+	tag, payload, err := marshalValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("bson: field %q: %w", key, err)
+	}
+	out := make([]byte, 0, len(key)+len(payload)+2)
+	out = append(out, tag)
+	out = append(out, []byte(key)...)
+	out = append(out, 0x00)
+	out = append(out, payload...)
+	return out, nil
+}
+
+func marshalValue(value interface{}) (byte, []byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return typeNull, nil, nil
+	case bool:
+		if v {
+			return typeBoolean, []byte{1}, nil
+		}
+		return typeBoolean, []byte{0}, nil
+	case int:
+		return marshalInt(int64(v))
+	case int32:
+		return marshalInt32(v)
+	case int64:
+		return marshalInt(v)
+	case float32:
+		return marshalDouble(float64(v))
+	case float64:
+		return marshalDouble(v)
+	case string:
+		return marshalString(v)
+	case time.Time:
+		return marshalDateTime(NewDateTimeFromTime(v))
+	case DateTime:
+		return marshalDateTime(v)
+	case ObjectID:
+		return typeObjectID, append([]byte{}, v[:]...), nil
+	case Binary:
+		b := make([]byte, 4, 5+len(v.Data))
+		binary.LittleEndian.PutUint32(b, uint32(len(v.Data)))
+		b = append(b, v.Subtype)
+		b = append(b, v.Data...)
+		return typeBinary, b, nil
+	case []byte:
+		return marshalValue(Binary{Subtype: BinaryGeneric, Data: v})
+	case RegEx:
+		b := append([]byte(v.Pattern), 0x00)
+		b = append(b, []byte(v.Options)...)
+		b = append(b, 0x00)
+		return typeRegex, b, nil
+	case Decimal128:
+		b := make([]byte, 16)
+		binary.LittleEndian.PutUint64(b[0:8], v.lo)
+		binary.LittleEndian.PutUint64(b[8:16], v.hi)
+		return typeDecimal128, b, nil
+	case D:
+		b, err := marshalDocument(v)
+		return typeDocument, b, err
+	case M:
+		doc, err := toD(v)
+		if err != nil {
+			return 0, nil, err
+		}
+		b, err := marshalDocument(doc)
+		return typeDocument, b, err
+	case A:
+		return marshalArray(v)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		doc, err := toD(value)
+		if err != nil {
+			return 0, nil, err
+		}
+		b, err := marshalDocument(doc)
+		return typeDocument, b, err
+	case reflect.Struct:
+		doc, err := structToD(rv)
+		if err != nil {
+			return 0, nil, err
+		}
+		b, err := marshalDocument(doc)
+		return typeDocument, b, err
+	case reflect.Slice, reflect.Array:
+		arr := make(A, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			arr[i] = rv.Index(i).Interface()
+		}
+		return marshalArray(arr)
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return typeNull, nil, nil
+		}
+		return marshalValue(rv.Elem().Interface())
+	}
+
+	return 0, nil, fmt.Errorf("bson: unsupported type %T", value)
+}
+
+func marshalArray(arr A) (byte, []byte, error) {
+	doc := make(D, len(arr))
+	for i, v := range arr {
+		doc[i] = E{Key: fmt.Sprint(i), Value: v}
+	}
+	b, err := marshalDocument(doc)
+	return typeArray, b, err
+}
+
+func marshalInt(v int64) (byte, []byte, error) {
+	if v >= math.MinInt32 && v <= math.MaxInt32 {
+		return marshalInt32(int32(v))
+	}
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(v))
+	return typeInt64, b, nil
+}
+
+func marshalInt32(v int32) (byte, []byte, error) {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, uint32(v))
+	return typeInt32, b, nil
+}
+
+func marshalDouble(v float64) (byte, []byte, error) {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+	return typeDouble, b, nil
+}
+
+func marshalString(v string) (byte, []byte, error) {
+	b := make([]byte, 4, 5+len(v))
+	binary.LittleEndian.PutUint32(b, uint32(len(v)+1))
+	b = append(b, []byte(v)...)
+	b = append(b, 0x00)
+	return typeString, b, nil
+}
+
+func marshalDateTime(dt DateTime) (byte, []byte, error) {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(int64(dt)))
+	return typeDateTime, b, nil
+}
+
+// toD normalizes D, M, and map[string]interface{} into a D. Map key order is
+// not meaningful in Go, so map-derived documents are sorted by key for
+// deterministic encoding.
+func toD(v interface{}) (D, error) {
+	switch m := v.(type) {
+	case D:
+		return m, nil
+	case M:
+		return mapToD(map[string]interface{}(m)), nil
+	case map[string]interface{}:
+		return mapToD(m), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("bson: map key must be string, got %s", rv.Type().Key())
+		}
+		out := make(map[string]interface{}, rv.Len())
+		for _, k := range rv.MapKeys() {
+			out[k.String()] = rv.MapIndex(k).Interface()
+		}
+		return mapToD(out), nil
+	case reflect.Struct:
+		return structToD(rv)
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil, fmt.Errorf("bson: cannot marshal nil pointer")
+		}
+		return toD(rv.Elem().Interface())
+	}
+
+	return nil, fmt.Errorf("bson: cannot marshal %T as a document", v)
+}
+
+func mapToD(m map[string]interface{}) D {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	doc := make(D, len(keys))
+	for i, k := range keys {
+		doc[i] = E{Key: k, Value: m[k]}
+	}
+	return doc
+}
+
+func structToD(rv reflect.Value) (D, error) {
+	t := rv.Type()
+	doc := make(D, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		name, omitempty, skip := parseBSONTag(field)
+		if skip {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if omitempty && isEmptyValue(fv) {
+			continue
+		}
+
+		doc = append(doc, E{Key: name, Value: fv.Interface()})
+	}
+	return doc, nil
+}
+
+func parseBSONTag(field reflect.StructField) (name string, omitempty, skip bool) {
+	tag := field.Tag.Get("bson")
+	if tag == "-" {
+		return "", false, true
+	}
+	if tag == "" {
+		return strings.ToLower(field.Name), false, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, false
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Array:
+		return v.Len() == 0
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package bson
+
+import (
+	"testing"
+	"time"
+)
+
+type person struct {
+	Name string `bson:"name"`
+	Age  int32  `bson:"age"`
+}
+
+func TestMarshalUnmarshalStruct(t *testing.T) {
+	in := person{Name: "Alice", Age: 30}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out person
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if out != in {
+		t.Fatalf("got %+v, want %+v", out, in)
+	}
+}
+
+func TestMarshalUnmarshalMap(t *testing.T) {
+	in := M{"name": "Bob", "age": int32(25)}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if out["name"] != "Bob" || out["age"] != int32(25) {
+		t.Fatalf("got %+v", out)
+	}
+}
+
+func TestMarshalUnmarshalNestedDocument(t *testing.T) {
+	in := M{
+		"name": "Carol",
+		"address": M{
+			"city": "Springfield",
+		},
+	}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	addr, ok := out["address"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected nested address document, got %T", out["address"])
+	}
+	if addr["city"] != "Springfield" {
+		t.Fatalf("got %+v", addr)
+	}
+}
+
+func TestMarshalUnmarshalDateTime(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	in := M{"createdAt": NewDateTimeFromTime(now)}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	dt, ok := out["createdAt"].(DateTime)
+	if !ok {
+		t.Fatalf("expected DateTime, got %T", out["createdAt"])
+	}
+	if !dt.Time().Equal(now) {
+		t.Fatalf("got %v, want %v", dt.Time(), now)
+	}
+}
+
+func TestMarshalUnmarshalObjectID(t *testing.T) {
+	id := NewObjectID()
+	in := M{"_id": id}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	got, ok := out["_id"].(ObjectID)
+	if !ok {
+		t.Fatalf("expected ObjectID, got %T", out["_id"])
+	}
+	if got != id {
+		t.Fatalf("got %v, want %v", got, id)
+	}
+}
+
+func TestMarshalUnmarshalArray(t *testing.T) {
+	in := M{"tags": A{"a", "b", "c"}}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	tags, ok := out["tags"].([]interface{})
+	if !ok || len(tags) != 3 {
+		t.Fatalf("got %+v", out["tags"])
+	}
+	if tags[0] != "a" || tags[1] != "b" || tags[2] != "c" {
+		t.Fatalf("got %+v", tags)
+	}
+}
+
+func TestMarshalUnmarshalDecimal128(t *testing.T) {
+	d := NewDecimal128(1, 2)
+	in := M{"amount": d}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	got, ok := out["amount"].(Decimal128)
+	if !ok {
+		t.Fatalf("expected Decimal128, got %T", out["amount"])
+	}
+	if got != d {
+		t.Fatalf("got %v, want %v", got, d)
+	}
+}
+
+func TestMarshalUnmarshalTypedMap(t *testing.T) {
+	in := struct {
+		Meta map[string]int32 `bson:"meta"`
+	}{Meta: map[string]int32{"a": 1, "b": 2}}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out struct {
+		Meta map[string]int32 `bson:"meta"`
+	}
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if out.Meta["a"] != 1 || out.Meta["b"] != 2 {
+		t.Fatalf("got %+v", out.Meta)
+	}
+}
+
+func TestMarshalUnmarshalStructSlice(t *testing.T) {
+	type item struct {
+		Name string `bson:"name"`
+	}
+	in := struct {
+		Tags  []string `bson:"tags"`
+		Items []item   `bson:"items"`
+	}{
+		Tags:  []string{"a", "b", "c"},
+		Items: []item{{Name: "one"}, {Name: "two"}},
+	}
+
+	data, err := Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out struct {
+		Tags  []string `bson:"tags"`
+		Items []item   `bson:"items"`
+	}
+	if err := Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(out.Tags) != 3 || out.Tags[0] != "a" || out.Tags[2] != "c" {
+		t.Fatalf("got %+v", out.Tags)
+	}
+	if len(out.Items) != 2 || out.Items[0].Name != "one" || out.Items[1].Name != "two" {
+		t.Fatalf("got %+v", out.Items)
+	}
+}
+
+func TestUnmarshalRequiresPointer(t *testing.T) {
+	data, err := Marshal(M{"x": 1})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := Unmarshal(data, out); err == nil {
+		t.Fatal("expected error unmarshaling into a non-pointer, got nil")
+	}
+}
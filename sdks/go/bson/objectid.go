@@ -0,0 +1,116 @@
+package bson
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ObjectID is a 12-byte unique document identifier, binary-compatible with
+// MongoDB's ObjectId: a 4-byte timestamp, 5 bytes of process-wide random
+// state, and a 3-byte incrementing counter.
+type ObjectID [12]byte
+
+var (
+	objectIDCounter = randUint32()
+	processUnique   = randProcessUnique()
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return binary.BigEndian.Uint32(b[:])
+}
+
+func randProcessUnique() [5]byte {
+	var b [5]byte
+	rand.Read(b[:])
+	return b
+}
+
+// NewObjectID generates a new, globally unique ObjectID.
+func NewObjectID() ObjectID {
+	var id ObjectID
+	binary.BigEndian.PutUint32(id[0:4], uint32(time.Now().Unix()))
+	copy(id[4:9], processUnique[:])
+	c := atomic.AddUint32(&objectIDCounter, 1)
+	id[9] = byte(c >> 16)
+	id[10] = byte(c >> 8)
+	id[11] = byte(c)
+	return id
+}
+
+// NilObjectID is the zero-value ObjectID.
+var NilObjectID ObjectID
+
+// IsZero reports whether id is the zero-value ObjectID.
+func (id ObjectID) IsZero() bool {
+	return id == NilObjectID
+}
+
+// Hex returns id as a lowercase hex string.
+func (id ObjectID) Hex() string {
+	return hex.EncodeToString(id[:])
+}
+
+// String returns id in MongoDB's ObjectID("...") debug format.
+func (id ObjectID) String() string {
+	return fmt.Sprintf("ObjectID(%q)", id.Hex())
+}
+
+// Timestamp returns the creation time encoded in id.
+func (id ObjectID) Timestamp() time.Time {
+	secs := binary.BigEndian.Uint32(id[0:4])
+	return time.Unix(int64(secs), 0).UTC()
+}
+
+// ObjectIDFromHex parses a 24-character hex string into an ObjectID.
+func ObjectIDFromHex(s string) (ObjectID, error) {
+	var id ObjectID
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return id, err
+	}
+	if len(b) != 12 {
+		return id, fmt.Errorf("bson: invalid ObjectID length %d", len(b))
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+// MarshalJSON renders id as MongoDB extended JSON: {"$oid": "..."}.
+func (id ObjectID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		OID string `json:"$oid"`
+	}{OID: id.Hex()})
+}
+
+// UnmarshalJSON accepts either extended JSON {"$oid": "..."} or a bare hex string.
+func (id *ObjectID) UnmarshalJSON(data []byte) error {
+	var ext struct {
+		OID string `json:"$oid"`
+	}
+	if err := json.Unmarshal(data, &ext); err == nil && ext.OID != "" {
+		parsed, err := ObjectIDFromHex(ext.OID)
+		if err != nil {
+			return err
+		}
+		*id = parsed
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ObjectIDFromHex(s)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
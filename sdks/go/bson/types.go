@@ -0,0 +1,64 @@
+package bson
+
+import "time"
+
+// D is an ordered BSON document, preserving key order the way
+// map[string]interface{} cannot. Use it when field order matters, e.g. for
+// $sort documents or index key specs.
+type D []E
+
+// E represents a single key/value pair within a D.
+type E struct {
+	Key   string
+	Value interface{}
+}
+
+// M is an unordered BSON document, equivalent to map[string]interface{}.
+type M map[string]interface{}
+
+// A is a BSON array.
+type A []interface{}
+
+// DateTime is a BSON UTC datetime: milliseconds since the Unix epoch.
+type DateTime int64
+
+// NewDateTimeFromTime converts a time.Time to a DateTime.
+func NewDateTimeFromTime(t time.Time) DateTime {
+	return DateTime(t.UnixMilli())
+}
+
+// Time converts dt to a time.Time in UTC.
+func (dt DateTime) Time() time.Time {
+	return time.UnixMilli(int64(dt)).UTC()
+}
+
+// Decimal128 is a 128-bit IEEE 754-2008 decimal floating point value, stored
+// as its two 64-bit halves.
+type Decimal128 struct {
+	hi, lo uint64
+}
+
+// NewDecimal128 builds a Decimal128 from its high and low 64-bit halves.
+func NewDecimal128(hi, lo uint64) Decimal128 {
+	return Decimal128{hi: hi, lo: lo}
+}
+
+// Binary is arbitrary binary data tagged with a BSON binary subtype.
+type Binary struct {
+	Subtype byte
+	Data    []byte
+}
+
+// Binary subtypes, matching the BSON spec.
+const (
+	BinaryGeneric  byte = 0x00
+	BinaryFunction byte = 0x01
+	BinaryUUID     byte = 0x04
+	BinaryMD5      byte = 0x05
+)
+
+// RegEx is a BSON regular expression, stored as its pattern and option flags.
+type RegEx struct {
+	Pattern string
+	Options string
+}
@@ -0,0 +1,245 @@
+package bson
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Unmarshal decodes a BSON document into v, which must be a pointer to a
+// map[string]interface{}, a D, or a struct.
+func Unmarshal(data []byte, v interface{}) error {
+	m, _, err := readDocument(data)
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("bson: Unmarshal requires a non-nil pointer, got %T", v)
+	}
+	if d, ok := v.(*D); ok {
+		*d = m
+		return nil
+	}
+
+	return assignDocument(docToMap(m), rv.Elem())
+}
+
+// readDocument parses a single BSON document starting at the beginning of
+// data, returning the decoded key/value pairs in encounter order and the
+// number of bytes consumed.
+func readDocument(data []byte) (D, int, error) {
+	if len(data) < 5 {
+		return nil, 0, fmt.Errorf("bson: document too short")
+	}
+	length := int(binary.LittleEndian.Uint32(data[0:4]))
+	if length > len(data) {
+		return nil, 0, fmt.Errorf("bson: document length %d exceeds buffer", length)
+	}
+
+	var doc D
+	pos := 4
+	for pos < length-1 {
+		tag := data[pos]
+		pos++
+
+		nameEnd := pos
+		for nameEnd < len(data) && data[nameEnd] != 0x00 {
+			nameEnd++
+		}
+		name := string(data[pos:nameEnd])
+		pos = nameEnd + 1
+
+		val, consumed, err := readValue(tag, data[pos:])
+		if err != nil {
+			return nil, 0, err
+		}
+		pos += consumed
+
+		doc = append(doc, E{Key: name, Value: val})
+	}
+
+	return doc, length, nil
+}
+
+func readValue(tag byte, data []byte) (interface{}, int, error) {
+	switch tag {
+	case typeDouble:
+		return math.Float64frombits(binary.LittleEndian.Uint64(data[0:8])), 8, nil
+	case typeString:
+		n := int(binary.LittleEndian.Uint32(data[0:4]))
+		return string(data[4 : 4+n-1]), 4 + n, nil
+	case typeDocument:
+		doc, n, err := readDocument(data)
+		if err != nil {
+			return nil, 0, err
+		}
+		return docToMap(doc), n, nil
+	case typeArray:
+		doc, n, err := readDocument(data)
+		if err != nil {
+			return nil, 0, err
+		}
+		arr := make([]interface{}, len(doc))
+		for i, e := range doc {
+			arr[i] = e.Value
+		}
+		return arr, n, nil
+	case typeBinary:
+		n := int(binary.LittleEndian.Uint32(data[0:4]))
+		subtype := data[4]
+		b := make([]byte, n)
+		copy(b, data[5:5+n])
+		return Binary{Subtype: subtype, Data: b}, 5 + n, nil
+	case typeObjectID:
+		var id ObjectID
+		copy(id[:], data[0:12])
+		return id, 12, nil
+	case typeBoolean:
+		return data[0] != 0, 1, nil
+	case typeDateTime:
+		ms := int64(binary.LittleEndian.Uint64(data[0:8]))
+		return DateTime(ms), 8, nil
+	case typeNull:
+		return nil, 0, nil
+	case typeRegex:
+		patEnd := indexZero(data)
+		pattern := string(data[:patEnd])
+		rest := data[patEnd+1:]
+		optEnd := indexZero(rest)
+		options := string(rest[:optEnd])
+		return RegEx{Pattern: pattern, Options: options}, patEnd + 1 + optEnd + 1, nil
+	case typeInt32:
+		return int32(binary.LittleEndian.Uint32(data[0:4])), 4, nil
+	case typeInt64:
+		return int64(binary.LittleEndian.Uint64(data[0:8])), 8, nil
+	case typeDecimal128:
+		lo := binary.LittleEndian.Uint64(data[0:8])
+		hi := binary.LittleEndian.Uint64(data[8:16])
+		return NewDecimal128(hi, lo), 16, nil
+	}
+	return nil, 0, fmt.Errorf("bson: unknown element type 0x%02x", tag)
+}
+
+func indexZero(b []byte) int {
+	for i, c := range b {
+		if c == 0x00 {
+			return i
+		}
+	}
+	return len(b)
+}
+
+func docToMap(doc D) map[string]interface{} {
+	m := make(map[string]interface{}, len(doc))
+	for _, e := range doc {
+		m[e.Key] = e.Value
+	}
+	return m
+}
+
+func assignDocument(m map[string]interface{}, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMap(rv.Type()))
+		}
+		elemType := rv.Type().Elem()
+		for k, v := range m {
+			elemVal := reflect.New(elemType).Elem()
+			if err := assignValue(v, elemVal); err != nil {
+				return fmt.Errorf("bson: map key %q: %w", k, err)
+			}
+			rv.SetMapIndex(reflect.ValueOf(k), elemVal)
+		}
+		return nil
+	case reflect.Struct:
+		return assignStruct(m, rv)
+	case reflect.Interface:
+		rv.Set(reflect.ValueOf(m))
+		return nil
+	}
+	return fmt.Errorf("bson: cannot unmarshal document into %s", rv.Type())
+}
+
+func assignStruct(m map[string]interface{}, rv reflect.Value) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, _, skip := parseBSONTag(field)
+		if skip {
+			continue
+		}
+		raw, ok := m[name]
+		if !ok {
+			continue
+		}
+		if err := assignValue(raw, rv.Field(i)); err != nil {
+			return fmt.Errorf("bson: field %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func assignValue(raw interface{}, field reflect.Value) error {
+	if raw == nil {
+		return nil
+	}
+	rawVal := reflect.ValueOf(raw)
+
+	if field.Kind() == reflect.Interface {
+		field.Set(rawVal)
+		return nil
+	}
+
+	if nested, ok := raw.(map[string]interface{}); ok {
+		return assignDocument(nested, field)
+	}
+
+	if rawSlice, ok := raw.([]interface{}); ok && field.Kind() == reflect.Slice {
+		out := reflect.MakeSlice(field.Type(), len(rawSlice), len(rawSlice))
+		for i, elem := range rawSlice {
+			if err := assignValue(elem, out.Index(i)); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+		field.Set(out)
+		return nil
+	}
+
+	if dt, ok := raw.(DateTime); ok && field.Type() == reflect.TypeOf(time.Time{}) {
+		field.Set(reflect.ValueOf(dt.Time()))
+		return nil
+	}
+
+	if rawVal.Type().ConvertibleTo(field.Type()) {
+		field.Set(rawVal.Convert(field.Type()))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(fmt.Sprint(raw))
+		return nil
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		switch n := raw.(type) {
+		case int32:
+			field.SetInt(int64(n))
+			return nil
+		case int64:
+			field.SetInt(n)
+			return nil
+		case float64:
+			field.SetInt(int64(n))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot assign %T to %s", raw, field.Type())
+}
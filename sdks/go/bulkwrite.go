@@ -0,0 +1,215 @@
+package plugport
+
+import (
+	"context"
+	"fmt"
+)
+
+// WriteModel is implemented by the write operations that can be batched
+// together in a BulkWrite call.
+type WriteModel interface {
+	writeModel()
+}
+
+// InsertOneModel inserts a single document as part of a BulkWrite.
+type InsertOneModel struct {
+	Document interface{}
+}
+
+func (InsertOneModel) writeModel() {}
+
+// UpdateOneModel updates a single document matching Filter as part of a BulkWrite.
+type UpdateOneModel struct {
+	Filter interface{}
+	Update interface{}
+	Upsert bool
+}
+
+func (UpdateOneModel) writeModel() {}
+
+// UpdateManyModel updates all documents matching Filter as part of a BulkWrite.
+type UpdateManyModel struct {
+	Filter interface{}
+	Update interface{}
+	Upsert bool
+}
+
+func (UpdateManyModel) writeModel() {}
+
+// ReplaceOneModel replaces a single document matching Filter as part of a BulkWrite.
+type ReplaceOneModel struct {
+	Filter      interface{}
+	Replacement interface{}
+	Upsert      bool
+}
+
+func (ReplaceOneModel) writeModel() {}
+
+// DeleteOneModel deletes a single document matching Filter as part of a BulkWrite.
+type DeleteOneModel struct {
+	Filter interface{}
+}
+
+func (DeleteOneModel) writeModel() {}
+
+// DeleteManyModel deletes all documents matching Filter as part of a BulkWrite.
+type DeleteManyModel struct {
+	Filter interface{}
+}
+
+func (DeleteManyModel) writeModel() {}
+
+// BulkWriteOptions contains options for the BulkWrite operation.
+type BulkWriteOptions struct {
+	// Ordered controls whether operations stop on the first error (true,
+	// the default) or continue executing the remaining operations (false).
+	Ordered *bool
+	// BypassDocumentValidation lets the write skip document validation.
+	BypassDocumentValidation *bool
+	// Comment attaches an arbitrary comment to the operation for logging.
+	Comment interface{}
+}
+
+// BulkWriteResult reports the aggregate effect of a BulkWrite call.
+type BulkWriteResult struct {
+	InsertedCount int
+	MatchedCount  int
+	ModifiedCount int
+	DeletedCount  int
+	UpsertedCount int
+	UpsertedIDs   map[int]interface{}
+	InsertedIDs   map[int]interface{}
+}
+
+// BulkWriteError describes the failure of a single operation within a BulkWrite.
+type BulkWriteError struct {
+	Index   int
+	Code    int
+	Message string
+	Request interface{}
+}
+
+func (e BulkWriteError) Error() string {
+	return fmt.Sprintf("plugport: bulk write error at index %d [%d]: %s", e.Index, e.Code, e.Message)
+}
+
+// BulkWriteException is returned alongside a partial BulkWriteResult when
+// one or more operations fail in an unordered BulkWrite.
+type BulkWriteException struct {
+	WriteErrors []BulkWriteError
+}
+
+func (e *BulkWriteException) Error() string {
+	return fmt.Sprintf("plugport: bulk write exception (%d write errors)", len(e.WriteErrors))
+}
+
+func encodeWriteModel(index int, model WriteModel) (map[string]interface{}, error) {
+	switch m := model.(type) {
+	case InsertOneModel:
+		return map[string]interface{}{"insertOne": map[string]interface{}{"document": m.Document}}, nil
+	case UpdateOneModel:
+		return map[string]interface{}{"updateOne": map[string]interface{}{"filter": m.Filter, "update": m.Update, "upsert": m.Upsert}}, nil
+	case UpdateManyModel:
+		return map[string]interface{}{"updateMany": map[string]interface{}{"filter": m.Filter, "update": m.Update, "upsert": m.Upsert}}, nil
+	case ReplaceOneModel:
+		return map[string]interface{}{"replaceOne": map[string]interface{}{"filter": m.Filter, "replacement": m.Replacement, "upsert": m.Upsert}}, nil
+	case DeleteOneModel:
+		return map[string]interface{}{"deleteOne": map[string]interface{}{"filter": m.Filter}}, nil
+	case DeleteManyModel:
+		return map[string]interface{}{"deleteMany": map[string]interface{}{"filter": m.Filter}}, nil
+	default:
+		return nil, fmt.Errorf("plugport: unsupported write model at index %d: %T", index, model)
+	}
+}
+
+// BulkWrite sends a batch of heterogeneous write operations to the server in
+// a single request. When opts[0].Ordered is false, a failed operation does
+// not stop the remaining operations from executing; any per-operation
+// failures are reported as a *BulkWriteException alongside the partial
+// BulkWriteResult so callers can retry just the failed ops.
+func (c *Collection) BulkWrite(ctx context.Context, models []WriteModel, opts ...BulkWriteOptions) (*BulkWriteResult, error) {
+	encoded := make([]map[string]interface{}, len(models))
+	for i, m := range models {
+		enc, err := encodeWriteModel(i, m)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = enc
+	}
+
+	ordered := true
+	body := map[string]interface{}{
+		"ops": encoded,
+	}
+	if len(opts) > 0 {
+		opt := opts[0]
+		if opt.Ordered != nil {
+			ordered = *opt.Ordered
+		}
+		if opt.BypassDocumentValidation != nil {
+			body["bypassDocumentValidation"] = *opt.BypassDocumentValidation
+		}
+		if opt.Comment != nil {
+			body["comment"] = opt.Comment
+		}
+	}
+	body["ordered"] = ordered
+	c.applyWriteConcern(body)
+
+	result, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/bulkWrite", c.name), body)
+	if err != nil {
+		return nil, err
+	}
+
+	bwResult := decodeBulkWriteResult(result)
+
+	writeErrors, _ := result["writeErrors"].([]interface{})
+	if len(writeErrors) > 0 {
+		exc := &BulkWriteException{}
+		for _, raw := range writeErrors {
+			we, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			index, _ := we["index"].(float64)
+			code, _ := we["code"].(float64)
+			msg, _ := we["errmsg"].(string)
+			exc.WriteErrors = append(exc.WriteErrors, BulkWriteError{
+				Index:   int(index),
+				Code:    int(code),
+				Message: msg,
+				Request: we["op"],
+			})
+		}
+		return bwResult, exc
+	}
+
+	return bwResult, nil
+}
+
+func decodeBulkWriteResult(result map[string]interface{}) *BulkWriteResult {
+	asInt := func(key string) int {
+		v, _ := result[key].(float64)
+		return int(v)
+	}
+	asIndexMap := func(key string) map[int]interface{} {
+		m, _ := result[key].(map[string]interface{})
+		out := make(map[int]interface{}, len(m))
+		for k, v := range m {
+			var idx int
+			fmt.Sscanf(k, "%d", &idx)
+			out[idx] = v
+		}
+		return out
+	}
+
+	return &BulkWriteResult{
+		InsertedCount: asInt("insertedCount"),
+		MatchedCount:  asInt("matchedCount"),
+		ModifiedCount: asInt("modifiedCount"),
+		DeletedCount:  asInt("deletedCount"),
+		UpsertedCount: asInt("upsertedCount"),
+		UpsertedIDs:   asIndexMap("upsertedIds"),
+		InsertedIDs:   asIndexMap("insertedIds"),
+	}
+}
@@ -0,0 +1,76 @@
+package plugport
+
+import "testing"
+
+func TestDecodeBulkWriteResult(t *testing.T) {
+	result := map[string]interface{}{
+		"insertedCount": float64(2),
+		"matchedCount":  float64(1),
+		"modifiedCount": float64(1),
+		"deletedCount":  float64(0),
+		"upsertedCount": float64(1),
+		"upsertedIds": map[string]interface{}{
+			"2": "upserted-id",
+		},
+		"insertedIds": map[string]interface{}{
+			"0": "id-0",
+			"1": "id-1",
+		},
+	}
+
+	bw := decodeBulkWriteResult(result)
+
+	if bw.InsertedCount != 2 || bw.MatchedCount != 1 || bw.ModifiedCount != 1 || bw.DeletedCount != 0 || bw.UpsertedCount != 1 {
+		t.Fatalf("unexpected counts: %+v", bw)
+	}
+	if bw.InsertedIDs[0] != "id-0" || bw.InsertedIDs[1] != "id-1" {
+		t.Fatalf("unexpected insertedIDs: %v", bw.InsertedIDs)
+	}
+	if bw.UpsertedIDs[2] != "upserted-id" {
+		t.Fatalf("unexpected upsertedIDs: %v", bw.UpsertedIDs)
+	}
+}
+
+func TestDecodeBulkWriteResultMissingFields(t *testing.T) {
+	bw := decodeBulkWriteResult(map[string]interface{}{})
+
+	if bw.InsertedCount != 0 || bw.MatchedCount != 0 || bw.ModifiedCount != 0 || bw.DeletedCount != 0 || bw.UpsertedCount != 0 {
+		t.Fatalf("expected zero counts, got %+v", bw)
+	}
+	if len(bw.InsertedIDs) != 0 || len(bw.UpsertedIDs) != 0 {
+		t.Fatalf("expected empty id maps, got %+v", bw)
+	}
+}
+
+func TestEncodeWriteModel(t *testing.T) {
+	tests := []struct {
+		name  string
+		model WriteModel
+		key   string
+	}{
+		{"insert", InsertOneModel{Document: map[string]interface{}{"x": 1}}, "insertOne"},
+		{"updateOne", UpdateOneModel{Filter: map[string]interface{}{"x": 1}, Update: map[string]interface{}{"$set": map[string]interface{}{"x": 2}}}, "updateOne"},
+		{"updateMany", UpdateManyModel{Filter: map[string]interface{}{"x": 1}, Update: map[string]interface{}{"$set": map[string]interface{}{"x": 2}}}, "updateMany"},
+		{"replaceOne", ReplaceOneModel{Filter: map[string]interface{}{"x": 1}, Replacement: map[string]interface{}{"x": 2}}, "replaceOne"},
+		{"deleteOne", DeleteOneModel{Filter: map[string]interface{}{"x": 1}}, "deleteOne"},
+		{"deleteMany", DeleteManyModel{Filter: map[string]interface{}{"x": 1}}, "deleteMany"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc, err := encodeWriteModel(0, tt.model)
+			if err != nil {
+				t.Fatalf("encodeWriteModel: %v", err)
+			}
+			if _, ok := enc[tt.key]; !ok {
+				t.Fatalf("expected key %q in %v", tt.key, enc)
+			}
+		})
+	}
+}
+
+func TestEncodeWriteModelUnsupported(t *testing.T) {
+	if _, err := encodeWriteModel(3, nil); err == nil {
+		t.Fatal("expected error for unsupported write model, got nil")
+	}
+}
@@ -0,0 +1,356 @@
+package plugport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// ChangeStreamTransport selects the wire protocol used to deliver change
+// events.
+type ChangeStreamTransport string
+
+const (
+	// TransportSSE streams events over Server-Sent Events. This is the default.
+	TransportSSE ChangeStreamTransport = "sse"
+	// TransportWebSocket streams events over a WebSocket connection.
+	TransportWebSocket ChangeStreamTransport = "websocket"
+)
+
+// ChangeStreamOptions contains options for Watch.
+type ChangeStreamOptions struct {
+	// FullDocument is "default" or "updateLookup".
+	FullDocument         string
+	StartAtOperationTime interface{}
+	MaxAwaitTime         time.Duration
+	BatchSize            int32
+	ResumeAfter          interface{}
+	StartAfter           interface{}
+	// Transport selects SSE (default) or WebSocket delivery.
+	Transport ChangeStreamTransport
+}
+
+// ChangeEvent is a single decoded change stream event.
+type ChangeEvent struct {
+	OperationType     string                 `json:"operationType"`
+	FullDocument      map[string]interface{} `json:"fullDocument"`
+	DocumentKey       map[string]interface{} `json:"documentKey"`
+	UpdateDescription map[string]interface{} `json:"updateDescription"`
+	ID                interface{}            `json:"_id"`
+	ClusterTime       interface{}            `json:"clusterTime"`
+}
+
+// ChangeStream is an open subscription to a stream of change events,
+// delivered over Server-Sent Events (the default) or, with
+// ChangeStreamOptions.Transport set to TransportWebSocket, a WebSocket
+// connection. Callers iterate with Next/Decode, similar to Cursor.
+type ChangeStream struct {
+	client   *Client
+	path     string
+	opts     ChangeStreamOptions
+	pipeline []interface{}
+	resp     *http.Response
+	scanner  *bufio.Scanner
+	ws       *websocket.Conn
+	current  map[string]interface{}
+	resumeAt interface{}
+	closed   bool
+	err      error
+}
+
+func newChangeStream(ctx context.Context, client *Client, path string, pipeline []interface{}, opts ChangeStreamOptions) (*ChangeStream, error) {
+	cs := &ChangeStream{client: client, path: path, opts: opts, pipeline: pipeline, resumeAt: opts.ResumeAfter}
+	if cs.resumeAt == nil {
+		cs.resumeAt = opts.StartAfter
+	}
+	if err := cs.connect(ctx, pipeline); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+func (cs *ChangeStream) connect(ctx context.Context, pipeline []interface{}) error {
+	body := map[string]interface{}{
+		"pipeline": pipeline,
+	}
+	if cs.opts.FullDocument != "" {
+		body["fullDocument"] = cs.opts.FullDocument
+	}
+	if cs.opts.StartAtOperationTime != nil {
+		body["startAtOperationTime"] = cs.opts.StartAtOperationTime
+	}
+	if cs.opts.MaxAwaitTime > 0 {
+		body["maxAwaitTimeMS"] = cs.opts.MaxAwaitTime.Milliseconds()
+	}
+	if cs.opts.BatchSize > 0 {
+		body["batchSize"] = cs.opts.BatchSize
+	}
+	if cs.resumeAt != nil {
+		body["resumeAfter"] = cs.resumeAt
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	if cs.opts.Transport == TransportWebSocket {
+		return cs.connectWebSocket(ctx, data)
+	}
+	return cs.connectSSE(ctx, data)
+}
+
+func (cs *ChangeStream) connectSSE(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", cs.client.baseURL+cs.path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	if cs.client.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+cs.client.apiKey)
+	}
+	applySessionHeaders(req)
+
+	resp, err := cs.client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		var errBody map[string]interface{}
+		json.Unmarshal(bodyBytes, &errBody)
+		msg, _ := errBody["errmsg"].(string)
+		code, _ := errBody["code"].(float64)
+		return &PlugPortError{Code: int(code), Message: msg}
+	}
+
+	cs.resp = resp
+	cs.scanner = bufio.NewScanner(resp.Body)
+	cs.scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return nil
+}
+
+// connectWebSocket dials cs.path over WebSocket and sends data, the same
+// watch configuration connectSSE would POST, as the connection's first
+// text frame.
+func (cs *ChangeStream) connectWebSocket(ctx context.Context, data []byte) error {
+	cfg, err := websocket.NewConfig(webSocketURL(cs.client.baseURL)+cs.path, cs.client.baseURL)
+	if err != nil {
+		return err
+	}
+	if cs.client.apiKey != "" {
+		cfg.Header.Set("Authorization", "Bearer "+cs.client.apiKey)
+	}
+	if s := sessionFromContext(ctx); s != nil {
+		cfg.Header.Set("X-PlugPort-Session", s.id)
+		cfg.Header.Set("X-PlugPort-TxnNumber", fmt.Sprint(s.txnNumber))
+	}
+
+	ws, err := websocket.DialConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if err := websocket.Message.Send(ws, string(data)); err != nil {
+		ws.Close()
+		return err
+	}
+
+	cs.ws = ws
+	return nil
+}
+
+// webSocketURL rewrites an http(s):// base URL to its ws(s):// equivalent.
+func webSocketURL(baseURL string) string {
+	switch {
+	case strings.HasPrefix(baseURL, "https://"):
+		return "wss://" + strings.TrimPrefix(baseURL, "https://")
+	case strings.HasPrefix(baseURL, "http://"):
+		return "ws://" + strings.TrimPrefix(baseURL, "http://")
+	default:
+		return baseURL
+	}
+}
+
+// changeStreamReconnectMaxBackoff caps the delay between reconnect attempts
+// so a persistent outage settles into steady polling rather than a busy loop.
+const changeStreamReconnectMaxBackoff = 30 * time.Second
+
+func (cs *ChangeStream) reconnect(ctx context.Context, pipeline []interface{}) error {
+	if cs.resp != nil {
+		cs.resp.Body.Close()
+		cs.resp = nil
+		cs.scanner = nil
+	}
+	if cs.ws != nil {
+		cs.ws.Close()
+		cs.ws = nil
+	}
+	return cs.connect(ctx, pipeline)
+}
+
+// Next blocks until a change event is available, returning false when the
+// stream is closed or an unrecoverable error occurs. Network drops are
+// retried automatically, resuming from the last seen resume token.
+func (cs *ChangeStream) Next(ctx context.Context) bool {
+	if cs.closed {
+		return false
+	}
+	if cs.scanner == nil && cs.ws == nil {
+		cs.err = fmt.Errorf("plugport: change stream is not connected")
+		return false
+	}
+
+	backoff := 10 * time.Millisecond
+
+	for {
+		evt, found, err := cs.readEvent()
+		if err != nil {
+			cs.err = err
+			return false
+		}
+
+		if found {
+			cs.current = evt
+			if id, ok := evt["_id"]; ok {
+				cs.resumeAt = id
+			}
+			return true
+		}
+
+		// The underlying connection ended, either cleanly (EOF) or with a
+		// transport error. Either way, resume from the last seen token,
+		// re-sending the original pipeline, retrying the reconnect itself
+		// (not just the read) until it succeeds or ctx is done, backing off
+		// between attempts so a persistent outage settles into steady
+		// polling rather than a busy loop.
+		for {
+			if reErr := cs.reconnect(ctx, cs.pipeline); reErr == nil {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				cs.err = ctx.Err()
+				return false
+			case <-time.After(jitter(backoff)):
+			}
+			backoff *= 2
+			if backoff > changeStreamReconnectMaxBackoff {
+				backoff = changeStreamReconnectMaxBackoff
+			}
+		}
+		backoff = 10 * time.Millisecond
+	}
+}
+
+// readEvent returns the next decoded change event for whichever transport
+// is active. found is false (with a nil error) when the underlying
+// connection ended and Next should reconnect.
+func (cs *ChangeStream) readEvent() (map[string]interface{}, bool, error) {
+	if cs.ws != nil {
+		return cs.readWebSocketEvent()
+	}
+	return cs.readSSEEvent()
+}
+
+func (cs *ChangeStream) readSSEEvent() (map[string]interface{}, bool, error) {
+	var data strings.Builder
+	for cs.scanner.Scan() {
+		line := cs.scanner.Text()
+		if line == "" {
+			if data.Len() > 0 {
+				var evt map[string]interface{}
+				if err := json.Unmarshal([]byte(data.String()), &evt); err != nil {
+					return nil, false, err
+				}
+				return evt, true, nil
+			}
+			continue
+		}
+		if payload, ok := strings.CutPrefix(line, "data:"); ok {
+			data.WriteString(strings.TrimSpace(payload))
+		}
+	}
+	return nil, false, nil
+}
+
+func (cs *ChangeStream) readWebSocketEvent() (map[string]interface{}, bool, error) {
+	var payload string
+	if err := websocket.Message.Receive(cs.ws, &payload); err != nil {
+		return nil, false, nil
+	}
+	var evt map[string]interface{}
+	if err := json.Unmarshal([]byte(payload), &evt); err != nil {
+		return nil, false, err
+	}
+	return evt, true, nil
+}
+
+// Decode unmarshals the current change event into v.
+func (cs *ChangeStream) Decode(v interface{}) error {
+	if cs.current == nil {
+		return fmt.Errorf("plugport: Decode called before Next or after stream exhausted")
+	}
+	return decodeDocument(cs.client.codec, cs.current, v)
+}
+
+// ResumeToken returns the resume token of the most recently observed event.
+func (cs *ChangeStream) ResumeToken() interface{} {
+	return cs.resumeAt
+}
+
+// Err returns the last error encountered by the stream, if any.
+func (cs *ChangeStream) Err() error {
+	return cs.err
+}
+
+// Close terminates the underlying connection.
+func (cs *ChangeStream) Close(ctx context.Context) error {
+	if cs.closed {
+		return nil
+	}
+	cs.closed = true
+	if cs.resp != nil {
+		return cs.resp.Body.Close()
+	}
+	if cs.ws != nil {
+		return cs.ws.Close()
+	}
+	return nil
+}
+
+// Watch opens a change stream over this collection.
+func (c *Collection) Watch(ctx context.Context, pipeline []interface{}, opts ...ChangeStreamOptions) (*ChangeStream, error) {
+	var opt ChangeStreamOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return newChangeStream(ctx, c.db.client, fmt.Sprintf("/api/v1/collections/%s/watch", c.name), pipeline, opt)
+}
+
+// Watch opens a change stream over every collection in this database.
+func (d *Database) Watch(ctx context.Context, pipeline []interface{}, opts ...ChangeStreamOptions) (*ChangeStream, error) {
+	var opt ChangeStreamOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return newChangeStream(ctx, d.client, fmt.Sprintf("/api/v1/db/%s/watch", d.name), pipeline, opt)
+}
+
+// Watch opens a change stream over the entire deployment.
+func (c *Client) Watch(ctx context.Context, pipeline []interface{}, opts ...ChangeStreamOptions) (*ChangeStream, error) {
+	var opt ChangeStreamOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return newChangeStream(ctx, c, "/api/v1/watch", pipeline, opt)
+}
@@ -0,0 +1,66 @@
+package plugport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestChangeStreamNextRetriesMultipleReconnectFailures(t *testing.T) {
+	var attempt int64
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&attempt, 1)
+		switch n {
+		case 1:
+			// Initial connect: stream one event, then end the response so
+			// Next's second call has to reconnect.
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("data: {\"_id\":\"1\",\"operationType\":\"insert\"}\n\n"))
+		case 2, 3:
+			// Two consecutive reconnect failures.
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"errmsg":"unavailable","code":1}`))
+		default:
+			// Third reconnect attempt succeeds.
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("data: {\"_id\":\"2\",\"operationType\":\"insert\"}\n\n"))
+		}
+	}))
+	defer srv.Close()
+
+	client := &Client{baseURL: srv.URL, codec: CodecJSON, httpClient: srv.Client()}
+
+	cs, err := newChangeStream(context.Background(), client, "/api/v1/watch", nil, ChangeStreamOptions{})
+	if err != nil {
+		t.Fatalf("newChangeStream: %v", err)
+	}
+
+	if !cs.Next(context.Background()) {
+		t.Fatalf("first Next failed: %v", cs.Err())
+	}
+	var first struct {
+		ID string `json:"_id"`
+	}
+	if err := cs.Decode(&first); err != nil || first.ID != "1" {
+		t.Fatalf("unexpected first event: %+v, err=%v", first, err)
+	}
+
+	if !cs.Next(context.Background()) {
+		t.Fatalf("second Next gave up instead of retrying past multiple reconnect failures: %v", cs.Err())
+	}
+	var second struct {
+		ID string `json:"_id"`
+	}
+	if err := cs.Decode(&second); err != nil || second.ID != "2" {
+		t.Fatalf("unexpected second event: %+v, err=%v", second, err)
+	}
+
+	if got := atomic.LoadInt64(&attempt); got < 4 {
+		t.Fatalf("expected at least 2 failed reconnect attempts before success, got %d total attempts", got)
+	}
+}
@@ -0,0 +1,57 @@
+package plugport
+
+import (
+	"encoding/json"
+
+	"github.com/PlugPort-Protocol/plugport/sdks/go/bson"
+)
+
+// Codec selects the wire encoding used for requests and responses.
+type Codec string
+
+const (
+	// CodecJSON sends and receives plain JSON. This is the default and
+	// matches the server's original transport.
+	CodecJSON Codec = "json"
+	// CodecBSON sends and receives BSON, preserving type fidelity (int64 vs
+	// float64, bson.ObjectID vs string, time.Time vs RFC3339 string) that
+	// JSON loses.
+	CodecBSON Codec = "bson"
+	// CodecExtendedJSON sends and receives MongoDB extended JSON, which
+	// represents BSON-specific types (ObjectID, DateTime, ...) as tagged
+	// JSON objects (e.g. {"$oid": "..."}) while staying human-readable.
+	CodecExtendedJSON Codec = "extjson"
+)
+
+func (codec Codec) contentType() string {
+	switch codec {
+	case CodecBSON:
+		return "application/bson"
+	case CodecExtendedJSON:
+		return "application/json; format=extended"
+	default:
+		return "application/json"
+	}
+}
+
+func (codec Codec) marshal(v interface{}) ([]byte, error) {
+	switch codec {
+	case CodecBSON:
+		return bson.Marshal(v)
+	case CodecExtendedJSON:
+		return bson.MarshalExtJSON(v)
+	default:
+		return json.Marshal(v)
+	}
+}
+
+func (codec Codec) unmarshal(data []byte, v interface{}) error {
+	switch codec {
+	case CodecBSON:
+		return bson.Unmarshal(data, v)
+	case CodecExtendedJSON:
+		return bson.UnmarshalExtJSON(data, v)
+	default:
+		return json.Unmarshal(data, v)
+	}
+}
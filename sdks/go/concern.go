@@ -0,0 +1,175 @@
+package plugport
+
+import "time"
+
+// ReadConcern specifies the consistency and isolation guarantees for reads.
+type ReadConcern struct {
+	Level string
+}
+
+// Read concern levels, matching the mongo driver's readconcern package.
+func ReadConcernLocal() *ReadConcern        { return &ReadConcern{Level: "local"} }
+func ReadConcernAvailable() *ReadConcern    { return &ReadConcern{Level: "available"} }
+func ReadConcernMajority() *ReadConcern     { return &ReadConcern{Level: "majority"} }
+func ReadConcernLinearizable() *ReadConcern { return &ReadConcern{Level: "linearizable"} }
+func ReadConcernSnapshot() *ReadConcern     { return &ReadConcern{Level: "snapshot"} }
+
+// WriteConcern specifies the acknowledgment level requested from the server
+// for writes.
+type WriteConcern struct {
+	// W is the number of nodes that must acknowledge the write, or a tag
+	// set name such as "majority".
+	W interface{}
+	// Journal requires the write to be committed to the on-disk journal.
+	Journal *bool
+	// WTimeout bounds how long to wait for the requested acknowledgment.
+	WTimeout time.Duration
+}
+
+// WriteConcernMajority requires acknowledgment from a majority of nodes.
+func WriteConcernMajority() *WriteConcern {
+	return &WriteConcern{W: "majority"}
+}
+
+// ReadPreference specifies which members of a replica set to read from.
+type ReadPreference struct {
+	Mode string
+}
+
+// Read preference modes, matching the mongo driver's readpref package.
+func ReadPreferencePrimary() *ReadPreference { return &ReadPreference{Mode: "primary"} }
+func ReadPreferencePrimaryPreferred() *ReadPreference {
+	return &ReadPreference{Mode: "primaryPreferred"}
+}
+func ReadPreferenceSecondary() *ReadPreference { return &ReadPreference{Mode: "secondary"} }
+func ReadPreferenceSecondaryPreferred() *ReadPreference {
+	return &ReadPreference{Mode: "secondaryPreferred"}
+}
+func ReadPreferenceNearest() *ReadPreference { return &ReadPreference{Mode: "nearest"} }
+
+func (wc *WriteConcern) toWire() map[string]interface{} {
+	if wc == nil {
+		return nil
+	}
+	wire := map[string]interface{}{}
+	if wc.W != nil {
+		wire["w"] = wc.W
+	}
+	if wc.Journal != nil {
+		wire["j"] = *wc.Journal
+	}
+	if wc.WTimeout > 0 {
+		wire["wtimeoutMS"] = wc.WTimeout.Milliseconds()
+	}
+	return wire
+}
+
+func (rc *ReadConcern) toWire() map[string]interface{} {
+	if rc == nil {
+		return nil
+	}
+	return map[string]interface{}{"level": rc.Level}
+}
+
+// WithReadConcern returns a shallow copy of d with its default read concern
+// overridden for collections obtained from it afterward.
+func (d *Database) WithReadConcern(rc *ReadConcern) *Database {
+	cp := *d
+	cp.readConcern = rc
+	return &cp
+}
+
+// WithWriteConcern returns a shallow copy of d with its default write
+// concern overridden for collections obtained from it afterward.
+func (d *Database) WithWriteConcern(wc *WriteConcern) *Database {
+	cp := *d
+	cp.writeConcern = wc
+	return &cp
+}
+
+// WithReadPreference returns a shallow copy of d with its default read
+// preference overridden for collections obtained from it afterward.
+func (d *Database) WithReadPreference(rp *ReadPreference) *Database {
+	cp := *d
+	cp.readPreference = rp
+	return &cp
+}
+
+// WithReadConcern returns a shallow copy of c with its read concern overridden.
+func (c *Collection) WithReadConcern(rc *ReadConcern) *Collection {
+	cp := *c
+	cp.readConcern = rc
+	return &cp
+}
+
+// WithWriteConcern returns a shallow copy of c with its write concern overridden.
+func (c *Collection) WithWriteConcern(wc *WriteConcern) *Collection {
+	cp := *c
+	cp.writeConcern = wc
+	return &cp
+}
+
+// WithReadPreference returns a shallow copy of c with its read preference overridden.
+func (c *Collection) WithReadPreference(rp *ReadPreference) *Collection {
+	cp := *c
+	cp.readPreference = rp
+	return &cp
+}
+
+// resolveReadConcern returns the effective read concern, falling back from
+// the collection to its database to the client's default.
+func (c *Collection) resolveReadConcern() *ReadConcern {
+	if c.readConcern != nil {
+		return c.readConcern
+	}
+	if c.db.readConcern != nil {
+		return c.db.readConcern
+	}
+	return c.db.client.readConcern
+}
+
+func (c *Collection) resolveWriteConcern() *WriteConcern {
+	if c.writeConcern != nil {
+		return c.writeConcern
+	}
+	if c.db.writeConcern != nil {
+		return c.db.writeConcern
+	}
+	return c.db.client.writeConcern
+}
+
+func (c *Collection) resolveReadPreference() *ReadPreference {
+	if c.readPreference != nil {
+		return c.readPreference
+	}
+	if c.db.readPreference != nil {
+		return c.db.readPreference
+	}
+	return c.db.client.readPreference
+}
+
+// applyReadConcern merges the effective read concern into a request body.
+func (c *Collection) applyReadConcern(body map[string]interface{}) map[string]interface{} {
+	if wire := c.resolveReadConcern().toWire(); wire != nil {
+		body["readConcern"] = wire
+	}
+	return body
+}
+
+// applyWriteConcern merges the effective write concern into a request body.
+func (c *Collection) applyWriteConcern(body map[string]interface{}) map[string]interface{} {
+	if wire := c.resolveWriteConcern().toWire(); wire != nil {
+		body["writeConcern"] = wire
+	}
+	return body
+}
+
+// readPreferenceHeaders returns the X-PlugPort-ReadPreference header for the
+// collection's effective read preference, or nil if none is set.
+func (c *Collection) readPreferenceHeaders() map[string]string {
+	rp := c.resolveReadPreference()
+	if rp == nil {
+		return nil
+	}
+	return map[string]string{"X-PlugPort-ReadPreference": rp.Mode}
+}
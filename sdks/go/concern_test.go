@@ -0,0 +1,93 @@
+package plugport
+
+import "testing"
+
+func TestResolveReadConcernFallsBackToDatabaseThenClient(t *testing.T) {
+	client := &Client{codec: CodecJSON, readConcern: ReadConcernMajority()}
+	db := &Database{client: client, name: "testdb"}
+	coll := &Collection{db: db, name: "testcoll"}
+
+	if got := coll.resolveReadConcern(); got != client.readConcern {
+		t.Fatalf("expected client default, got %+v", got)
+	}
+
+	db.readConcern = ReadConcernLocal()
+	if got := coll.resolveReadConcern(); got != db.readConcern {
+		t.Fatalf("expected database override, got %+v", got)
+	}
+
+	coll.readConcern = ReadConcernSnapshot()
+	if got := coll.resolveReadConcern(); got != coll.readConcern {
+		t.Fatalf("expected collection override, got %+v", got)
+	}
+}
+
+func TestResolveWriteConcernFallsBackToDatabaseThenClient(t *testing.T) {
+	client := &Client{codec: CodecJSON, writeConcern: WriteConcernMajority()}
+	db := &Database{client: client, name: "testdb"}
+	coll := &Collection{db: db, name: "testcoll"}
+
+	if got := coll.resolveWriteConcern(); got != client.writeConcern {
+		t.Fatalf("expected client default, got %+v", got)
+	}
+
+	db.writeConcern = &WriteConcern{W: 1}
+	if got := coll.resolveWriteConcern(); got != db.writeConcern {
+		t.Fatalf("expected database override, got %+v", got)
+	}
+
+	coll.writeConcern = &WriteConcern{W: 2}
+	if got := coll.resolveWriteConcern(); got != coll.writeConcern {
+		t.Fatalf("expected collection override, got %+v", got)
+	}
+}
+
+func TestResolveReadPreferenceFallsBackToDatabaseThenClient(t *testing.T) {
+	client := &Client{codec: CodecJSON, readPreference: ReadPreferencePrimary()}
+	db := &Database{client: client, name: "testdb"}
+	coll := &Collection{db: db, name: "testcoll"}
+
+	if got := coll.resolveReadPreference(); got != client.readPreference {
+		t.Fatalf("expected client default, got %+v", got)
+	}
+
+	db.readPreference = ReadPreferenceNearest()
+	if got := coll.resolveReadPreference(); got != db.readPreference {
+		t.Fatalf("expected database override, got %+v", got)
+	}
+
+	coll.readPreference = ReadPreferenceSecondaryPreferred()
+	if got := coll.resolveReadPreference(); got != coll.readPreference {
+		t.Fatalf("expected collection override, got %+v", got)
+	}
+	if headers := coll.readPreferenceHeaders(); headers["X-PlugPort-ReadPreference"] != "secondaryPreferred" {
+		t.Fatalf("got %+v", headers)
+	}
+}
+
+func TestWriteConcernToWire(t *testing.T) {
+	j := true
+	wc := &WriteConcern{W: "majority", Journal: &j}
+	wire := wc.toWire()
+	if wire["w"] != "majority" || wire["j"] != true {
+		t.Fatalf("got %+v", wire)
+	}
+
+	var nilWC *WriteConcern
+	if wire := nilWC.toWire(); wire != nil {
+		t.Fatalf("expected nil wire for nil WriteConcern, got %+v", wire)
+	}
+}
+
+func TestReadConcernToWire(t *testing.T) {
+	rc := ReadConcernMajority()
+	wire := rc.toWire()
+	if wire["level"] != "majority" {
+		t.Fatalf("got %+v", wire)
+	}
+
+	var nilRC *ReadConcern
+	if wire := nilRC.toWire(); wire != nil {
+		t.Fatalf("expected nil wire for nil ReadConcern, got %+v", wire)
+	}
+}
@@ -0,0 +1,153 @@
+package plugport
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Cursor iterates over the results of a find or aggregate operation, lazily
+// fetching additional batches from the server as needed.
+type Cursor struct {
+	coll      *Collection
+	id        int64
+	batch     []interface{}
+	pos       int
+	current   map[string]interface{}
+	closed    bool
+	err       error
+	batchSize int32
+}
+
+func newCursor(coll *Collection, raw interface{}) (*Cursor, error) {
+	cur := &Cursor{coll: coll}
+
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return cur, nil
+	}
+
+	if batch, ok := m["firstBatch"].([]interface{}); ok {
+		cur.batch = batch
+	}
+	if id, ok := m["id"].(float64); ok {
+		cur.id = int64(id)
+	}
+
+	return cur, nil
+}
+
+// ID returns the server-side cursor ID, or 0 if the cursor has been exhausted
+// or was never opened server-side.
+func (cur *Cursor) ID() int64 {
+	return cur.id
+}
+
+// Err returns the last error encountered by the cursor, if any.
+func (cur *Cursor) Err() error {
+	return cur.err
+}
+
+// Next advances the cursor to the next document, fetching the next batch
+// from the server via /api/v1/cursors/{id}/next when the current batch is
+// exhausted. It returns false when there are no more documents or an error
+// occurred.
+func (cur *Cursor) Next(ctx context.Context) bool {
+	if cur.closed || cur.err != nil {
+		return false
+	}
+
+	for cur.pos >= len(cur.batch) {
+		if cur.id == 0 {
+			return false
+		}
+		if err := cur.getMore(ctx); err != nil {
+			cur.err = err
+			return false
+		}
+	}
+
+	doc, ok := cur.batch[cur.pos].(map[string]interface{})
+	cur.pos++
+	if !ok {
+		cur.err = fmt.Errorf("plugport: unexpected document shape in cursor batch")
+		return false
+	}
+	cur.current = doc
+	return true
+}
+
+func (cur *Cursor) getMore(ctx context.Context) error {
+	path := fmt.Sprintf("/api/v1/cursors/%d/next", cur.id)
+	if cur.batchSize > 0 {
+		path += fmt.Sprintf("?batchSize=%d", cur.batchSize)
+	}
+
+	result, err := cur.coll.db.client.doGet(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	m, ok := result["cursor"].(map[string]interface{})
+	if !ok {
+		cur.id = 0
+		cur.batch = nil
+		cur.pos = 0
+		return nil
+	}
+
+	batch, _ := m["nextBatch"].([]interface{})
+	cur.batch = batch
+	cur.pos = 0
+
+	if id, ok := m["id"].(float64); ok {
+		cur.id = int64(id)
+	} else {
+		cur.id = 0
+	}
+
+	return nil
+}
+
+// Decode unmarshals the current document into v.
+func (cur *Cursor) Decode(v interface{}) error {
+	if cur.current == nil {
+		return fmt.Errorf("plugport: Decode called before Next or after cursor exhausted")
+	}
+	return decodeDocument(cur.coll.db.client.codec, cur.current, v)
+}
+
+// All drains the cursor into out, which must be a pointer to a slice.
+func (cur *Cursor) All(ctx context.Context, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("plugport: All requires a non-nil pointer to a slice, got %T", out)
+	}
+	docs := reflect.MakeSlice(rv.Elem().Type(), 0, 0)
+	for cur.Next(ctx) {
+		elem := reflect.New(docs.Type().Elem())
+		if err := decodeDocument(cur.coll.db.client.codec, cur.current, elem.Interface()); err != nil {
+			return err
+		}
+		docs = reflect.Append(docs, elem.Elem())
+	}
+	if err := cur.Err(); err != nil {
+		return err
+	}
+	rv.Elem().Set(docs)
+	return nil
+}
+
+// Close releases any server-side resources associated with the cursor.
+func (cur *Cursor) Close(ctx context.Context) error {
+	if cur.closed {
+		return nil
+	}
+	cur.closed = true
+	if cur.id == 0 {
+		return nil
+	}
+	_, err := cur.coll.db.client.doPost(ctx, fmt.Sprintf("/api/v1/cursors/%d/close", cur.id), map[string]interface{}{})
+	cur.id = 0
+	return err
+}
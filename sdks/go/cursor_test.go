@@ -0,0 +1,106 @@
+package plugport
+
+import (
+	"context"
+	"testing"
+)
+
+func testCollection() *Collection {
+	client := &Client{codec: CodecJSON}
+	db := &Database{client: client, name: "testdb"}
+	return &Collection{db: db, name: "testcoll"}
+}
+
+func TestCursorNextDecode(t *testing.T) {
+	coll := testCollection()
+	raw := map[string]interface{}{
+		"firstBatch": []interface{}{
+			map[string]interface{}{"name": "Alice"},
+			map[string]interface{}{"name": "Bob"},
+		},
+		"id": float64(0),
+	}
+
+	cur, err := newCursor(coll, raw)
+	if err != nil {
+		t.Fatalf("newCursor: %v", err)
+	}
+
+	var got []string
+	for cur.Next(context.Background()) {
+		var doc struct {
+			Name string `json:"name"`
+		}
+		if err := cur.Decode(&doc); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		got = append(got, doc.Name)
+	}
+	if err := cur.Err(); err != nil {
+		t.Fatalf("Err: %v", err)
+	}
+
+	if len(got) != 2 || got[0] != "Alice" || got[1] != "Bob" {
+		t.Fatalf("got %v, want [Alice Bob]", got)
+	}
+}
+
+func TestCursorAll(t *testing.T) {
+	coll := testCollection()
+	raw := map[string]interface{}{
+		"firstBatch": []interface{}{
+			map[string]interface{}{"name": "Alice"},
+			map[string]interface{}{"name": "Bob"},
+		},
+		"id": float64(0),
+	}
+
+	cur, err := newCursor(coll, raw)
+	if err != nil {
+		t.Fatalf("newCursor: %v", err)
+	}
+
+	var out []struct {
+		Name string `json:"name"`
+	}
+	if err := cur.All(context.Background(), &out); err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(out) != 2 || out[0].Name != "Alice" || out[1].Name != "Bob" {
+		t.Fatalf("got %+v, want [Alice Bob]", out)
+	}
+}
+
+func TestCursorDecodeBeforeNext(t *testing.T) {
+	coll := testCollection()
+	cur, err := newCursor(coll, map[string]interface{}{"id": float64(0)})
+	if err != nil {
+		t.Fatalf("newCursor: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := cur.Decode(&doc); err == nil {
+		t.Fatal("expected error decoding before Next, got nil")
+	}
+}
+
+func TestCursorExhaustedWithoutServerCursor(t *testing.T) {
+	coll := testCollection()
+	cur, err := newCursor(coll, map[string]interface{}{
+		"firstBatch": []interface{}{map[string]interface{}{"name": "Alice"}},
+		"id":         float64(0),
+	})
+	if err != nil {
+		t.Fatalf("newCursor: %v", err)
+	}
+
+	if !cur.Next(context.Background()) {
+		t.Fatal("expected first Next to succeed")
+	}
+	if cur.Next(context.Background()) {
+		t.Fatal("expected second Next to report exhaustion, id is 0 so no getMore is possible")
+	}
+	if cur.Err() != nil {
+		t.Fatalf("Err: %v", cur.Err())
+	}
+}
@@ -0,0 +1,15 @@
+package plugport
+
+// decodeDocument re-encodes src (an already-decoded document, e.g. a
+// map[string]interface{} produced by a prior codec.unmarshal) and decodes it
+// into dst using the given codec. Routing through the same codec that
+// produced the document — rather than always encoding/json — is required to
+// preserve BSON-specific type fidelity (bson.DateTime, bson.ObjectID,
+// bson.Decimal128, ...) when the client is configured with CodecBSON.
+func decodeDocument(codec Codec, src, dst interface{}) error {
+	data, err := codec.marshal(src)
+	if err != nil {
+		return err
+	}
+	return codec.unmarshal(data, dst)
+}
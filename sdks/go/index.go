@@ -0,0 +1,165 @@
+package plugport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PlugPort-Protocol/plugport/sdks/go/bson"
+)
+
+// IndexModel describes a single index to create, mirroring mongo-go-driver's
+// mongo.IndexModel.
+type IndexModel struct {
+	Keys    bson.D
+	Options *IndexOptions
+}
+
+// IndexOptions configures an IndexModel.
+type IndexOptions struct {
+	Name                    *string
+	Unique                  *bool
+	Sparse                  *bool
+	ExpireAfterSeconds      *int32
+	PartialFilterExpression interface{}
+	Collation               map[string]interface{}
+	TextIndexVersion        *int32
+	DefaultLanguage         *string
+	Weights                 map[string]int32
+	TwoDSphereIndexVersion  *int32
+	Background              *bool
+	Hidden                  *bool
+}
+
+func (m IndexModel) toWire() map[string]interface{} {
+	wire := map[string]interface{}{
+		"key": m.Keys,
+	}
+	opt := m.Options
+	if opt == nil {
+		return wire
+	}
+
+	if opt.Name != nil {
+		wire["name"] = *opt.Name
+	}
+	if opt.Unique != nil {
+		wire["unique"] = *opt.Unique
+	}
+	if opt.Sparse != nil {
+		wire["sparse"] = *opt.Sparse
+	}
+	if opt.ExpireAfterSeconds != nil {
+		wire["expireAfterSeconds"] = *opt.ExpireAfterSeconds
+	}
+	if opt.PartialFilterExpression != nil {
+		wire["partialFilterExpression"] = opt.PartialFilterExpression
+	}
+	if opt.Collation != nil {
+		wire["collation"] = opt.Collation
+	}
+	if opt.TextIndexVersion != nil {
+		wire["textIndexVersion"] = *opt.TextIndexVersion
+	}
+	if opt.DefaultLanguage != nil {
+		wire["default_language"] = *opt.DefaultLanguage
+	}
+	if opt.Weights != nil {
+		wire["weights"] = opt.Weights
+	}
+	if opt.TwoDSphereIndexVersion != nil {
+		wire["2dsphereIndexVersion"] = *opt.TwoDSphereIndexVersion
+	}
+	if opt.Background != nil {
+		wire["background"] = *opt.Background
+	}
+	if opt.Hidden != nil {
+		wire["hidden"] = *opt.Hidden
+	}
+
+	return wire
+}
+
+// IndexView provides access to the index management commands for a collection.
+type IndexView struct {
+	coll *Collection
+}
+
+// Indexes returns an IndexView for managing this collection's indexes.
+func (c *Collection) Indexes() *IndexView {
+	return &IndexView{coll: c}
+}
+
+// CreateOne creates a single index and returns its name.
+func (iv *IndexView) CreateOne(ctx context.Context, model IndexModel) (string, error) {
+	names, err := iv.CreateMany(ctx, []IndexModel{model})
+	if err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("plugport: server did not return an index name")
+	}
+	return names[0], nil
+}
+
+// CreateMany creates multiple indexes atomically in a single request and
+// returns their names in the same order as models.
+func (iv *IndexView) CreateMany(ctx context.Context, models []IndexModel) ([]string, error) {
+	wire := make([]map[string]interface{}, len(models))
+	for i, m := range models {
+		wire[i] = m.toWire()
+	}
+
+	result, err := iv.coll.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/indexes", iv.coll.name), map[string]interface{}{
+		"indexes": wire,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	raw, _ := result["indexesCreated"].([]interface{})
+	names := make([]string, 0, len(raw))
+	for _, r := range raw {
+		if m, ok := r.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// DropOne drops the index with the given name.
+func (iv *IndexView) DropOne(ctx context.Context, name string) error {
+	_, err := iv.coll.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/indexes/drop", iv.coll.name), map[string]interface{}{
+		"name": name,
+	})
+	return err
+}
+
+// DropAll drops every index on the collection except the default _id index.
+func (iv *IndexView) DropAll(ctx context.Context) error {
+	_, err := iv.coll.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/indexes/drop", iv.coll.name), map[string]interface{}{
+		"name": "*",
+	})
+	return err
+}
+
+// List returns a cursor over the collection's index specifications.
+func (iv *IndexView) List(ctx context.Context) (*Cursor, error) {
+	result, err := iv.coll.db.client.doGet(ctx, fmt.Sprintf("/api/v1/collections/%s/indexes", iv.coll.name))
+	if err != nil {
+		return nil, err
+	}
+	return newCursor(iv.coll, result["cursor"])
+}
+
+// CreateIndex creates a single-field index.
+//
+// Deprecated: use Indexes().CreateOne with an IndexModel instead, which
+// supports compound, TTL, partial, text, and geo indexes.
+func (c *Collection) CreateIndex(ctx context.Context, field string, unique bool) (string, error) {
+	return c.Indexes().CreateOne(ctx, IndexModel{
+		Keys:    bson.D{{Key: field, Value: 1}},
+		Options: &IndexOptions{Unique: &unique},
+	})
+}
@@ -0,0 +1,80 @@
+package plugport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/PlugPort-Protocol/plugport/sdks/go/bson"
+)
+
+func TestIndexModelToWire(t *testing.T) {
+	name := "by_email"
+	unique := true
+	m := IndexModel{
+		Keys: bson.D{{Key: "email", Value: 1}},
+		Options: &IndexOptions{
+			Name:   &name,
+			Unique: &unique,
+		},
+	}
+
+	wire := m.toWire()
+	if wire["name"] != name {
+		t.Fatalf("got %+v", wire)
+	}
+	if wire["unique"] != true {
+		t.Fatalf("got %+v", wire)
+	}
+	if _, ok := wire["key"].(bson.D); !ok {
+		t.Fatalf("expected key to be a bson.D, got %T", wire["key"])
+	}
+}
+
+func TestIndexModelToWireNoOptions(t *testing.T) {
+	m := IndexModel{Keys: bson.D{{Key: "email", Value: 1}}}
+	wire := m.toWire()
+	if len(wire) != 1 {
+		t.Fatalf("expected only the key entry, got %+v", wire)
+	}
+}
+
+func TestIndexViewCreateOne(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"indexesCreated": []interface{}{
+				map[string]interface{}{"name": "email_1"},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	client := &Client{baseURL: srv.URL, codec: CodecJSON, httpClient: srv.Client()}
+	coll := client.Database("testdb").Collection("testcoll")
+
+	name, err := coll.Indexes().CreateOne(context.Background(), IndexModel{
+		Keys: bson.D{{Key: "email", Value: 1}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOne: %v", err)
+	}
+	if name != "email_1" {
+		t.Fatalf("got %q, want email_1", name)
+	}
+}
+
+func TestIndexViewCreateOneNoNameReturned(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"indexesCreated": []interface{}{}})
+	}))
+	defer srv.Close()
+
+	client := &Client{baseURL: srv.URL, codec: CodecJSON, httpClient: srv.Client()}
+	coll := client.Database("testdb").Collection("testcoll")
+
+	if _, err := coll.Indexes().CreateOne(context.Background(), IndexModel{Keys: bson.D{{Key: "email", Value: 1}}}); err == nil {
+		t.Fatal("expected error when server returns no index names")
+	}
+}
@@ -0,0 +1,115 @@
+package plugport
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Monitor receives lifecycle events for every command the client sends,
+// mirroring mongo-go-driver's event.CommandMonitor. Change stream
+// connections opened by Watch do not emit events. Implementations must be
+// safe for concurrent use.
+type Monitor interface {
+	Started(ctx context.Context, evt CommandStartedEvent)
+	Succeeded(ctx context.Context, evt CommandSucceededEvent)
+	Failed(ctx context.Context, evt CommandFailedEvent)
+}
+
+// CommandStartedEvent is emitted immediately before a command is sent.
+type CommandStartedEvent struct {
+	RequestID      int64
+	CommandName    string
+	DatabaseName   string
+	CollectionName string
+}
+
+// CommandSucceededEvent is emitted after a command completes successfully.
+type CommandSucceededEvent struct {
+	RequestID      int64
+	CommandName    string
+	DatabaseName   string
+	CollectionName string
+	Duration       time.Duration
+	StatusCode     int
+}
+
+// CommandFailedEvent is emitted after a command fails, either at the
+// transport level or with an error response from the server.
+type CommandFailedEvent struct {
+	RequestID      int64
+	CommandName    string
+	DatabaseName   string
+	CollectionName string
+	Duration       time.Duration
+	Failure        string
+	StatusCode     int
+}
+
+var monitorRequestID int64
+
+// commandInfo derives a best-effort command/database/collection name from a
+// PlugPort REST path, e.g. "/api/v1/collections/users/find" -> ("find", "", "users").
+func commandInfo(path string) (command, database, collection string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) == 0 {
+		return "", "", ""
+	}
+	command = segments[len(segments)-1]
+	for i, s := range segments {
+		if s == "collections" && i+1 < len(segments) {
+			collection = segments[i+1]
+		}
+		if s == "db" && i+1 < len(segments) {
+			database = segments[i+1]
+		}
+	}
+	return command, database, collection
+}
+
+func (c *Client) monitorStart(ctx context.Context, path string) (int64, time.Time) {
+	if c.monitor == nil {
+		return 0, time.Time{}
+	}
+	id := atomic.AddInt64(&monitorRequestID, 1)
+	command, database, collection := commandInfo(path)
+	c.monitor.Started(ctx, CommandStartedEvent{
+		RequestID:      id,
+		CommandName:    command,
+		DatabaseName:   database,
+		CollectionName: collection,
+	})
+	return id, time.Now()
+}
+
+func (c *Client) monitorSucceed(ctx context.Context, path string, id int64, start time.Time, statusCode int) {
+	if c.monitor == nil || id == 0 {
+		return
+	}
+	command, database, collection := commandInfo(path)
+	c.monitor.Succeeded(ctx, CommandSucceededEvent{
+		RequestID:      id,
+		CommandName:    command,
+		DatabaseName:   database,
+		CollectionName: collection,
+		Duration:       time.Since(start),
+		StatusCode:     statusCode,
+	})
+}
+
+func (c *Client) monitorFail(ctx context.Context, path string, id int64, start time.Time, statusCode int, failure string) {
+	if c.monitor == nil || id == 0 {
+		return
+	}
+	command, database, collection := commandInfo(path)
+	c.monitor.Failed(ctx, CommandFailedEvent{
+		RequestID:      id,
+		CommandName:    command,
+		DatabaseName:   database,
+		CollectionName: collection,
+		Duration:       time.Since(start),
+		Failure:        failure,
+		StatusCode:     statusCode,
+	})
+}
@@ -0,0 +1,118 @@
+package plugport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestCommandInfoParsesPath(t *testing.T) {
+	cases := []struct {
+		path       string
+		command    string
+		database   string
+		collection string
+	}{
+		{"/api/v1/collections/users/find", "find", "", "users"},
+		{"/api/v1/collections/users/insertOne", "insertOne", "", "users"},
+		{"/health", "health", "", ""},
+		{"", "", "", ""},
+	}
+
+	for _, c := range cases {
+		command, database, collection := commandInfo(c.path)
+		if command != c.command || database != c.database || collection != c.collection {
+			t.Fatalf("commandInfo(%q) = (%q, %q, %q), want (%q, %q, %q)",
+				c.path, command, database, collection, c.command, c.database, c.collection)
+		}
+	}
+}
+
+type recordingMonitor struct {
+	mu        sync.Mutex
+	started   []CommandStartedEvent
+	succeeded []CommandSucceededEvent
+	failed    []CommandFailedEvent
+}
+
+func (m *recordingMonitor) Started(ctx context.Context, evt CommandStartedEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started = append(m.started, evt)
+}
+
+func (m *recordingMonitor) Succeeded(ctx context.Context, evt CommandSucceededEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.succeeded = append(m.succeeded, evt)
+}
+
+func (m *recordingMonitor) Failed(ctx context.Context, evt CommandFailedEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed = append(m.failed, evt)
+}
+
+func TestMonitorReceivesStartedAndSucceeded(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"insertedId": "id-1"})
+	}))
+	defer srv.Close()
+
+	mon := &recordingMonitor{}
+	client := &Client{baseURL: srv.URL, codec: CodecJSON, httpClient: srv.Client(), monitor: mon}
+	coll := client.Database("testdb").Collection("users")
+
+	if _, err := coll.InsertOne(context.Background(), map[string]interface{}{"x": 1}); err != nil {
+		t.Fatalf("InsertOne: %v", err)
+	}
+
+	mon.mu.Lock()
+	defer mon.mu.Unlock()
+	if len(mon.started) != 1 || mon.started[0].CommandName != "insertOne" || mon.started[0].CollectionName != "users" {
+		t.Fatalf("got started events %+v", mon.started)
+	}
+	if len(mon.succeeded) != 1 || mon.succeeded[0].StatusCode != http.StatusOK {
+		t.Fatalf("got succeeded events %+v", mon.succeeded)
+	}
+	if len(mon.failed) != 0 {
+		t.Fatalf("expected no failures, got %+v", mon.failed)
+	}
+}
+
+func TestMonitorReceivesFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{"errmsg": "boom", "code": float64(1)})
+	}))
+	defer srv.Close()
+
+	mon := &recordingMonitor{}
+	client := &Client{baseURL: srv.URL, codec: CodecJSON, httpClient: srv.Client(), monitor: mon}
+	coll := client.Database("testdb").Collection("users")
+
+	if _, err := coll.InsertOne(context.Background(), map[string]interface{}{"x": 1}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	mon.mu.Lock()
+	defer mon.mu.Unlock()
+	if len(mon.failed) != 1 || mon.failed[0].Failure != "boom" {
+		t.Fatalf("got failed events %+v", mon.failed)
+	}
+}
+
+func TestMonitorNotConsultedWhenNil(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok"})
+	}))
+	defer srv.Close()
+
+	client := &Client{baseURL: srv.URL, codec: CodecJSON, httpClient: srv.Client()}
+	if _, err := client.Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Package otelplugport provides OpenTelemetry and Prometheus instrumentation
+// for plugport.Client, implementing plugport.Monitor.
+package otelplugport
+
+import (
+	"context"
+	"sync"
+
+	plugport "github.com/PlugPort-Protocol/plugport/sdks/go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/PlugPort-Protocol/plugport/sdks/go/otelplugport"
+
+// Monitor implements plugport.Monitor by recording each command as an
+// OpenTelemetry span, following the semantic conventions used by other
+// database client instrumentations (db.name, db.collection, db.operation,
+// net.peer.name).
+type Monitor struct {
+	tracer  trace.Tracer
+	peer    string
+	spans   map[int64]trace.Span
+	spansMu sync.Mutex
+}
+
+// NewMonitor builds a Monitor that creates spans on the given tracer
+// provider. peerName is recorded as the net.peer.name attribute, e.g. the
+// PlugPort deployment's hostname.
+func NewMonitor(tp trace.TracerProvider, peerName string) *Monitor {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return &Monitor{
+		tracer: tp.Tracer(instrumentationName),
+		peer:   peerName,
+		spans:  make(map[int64]trace.Span),
+	}
+}
+
+// Started implements plugport.Monitor.
+func (m *Monitor) Started(ctx context.Context, evt plugport.CommandStartedEvent) {
+	_, span := m.tracer.Start(ctx, evt.CommandName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.name", evt.DatabaseName),
+			attribute.String("db.collection", evt.CollectionName),
+			attribute.String("db.operation", evt.CommandName),
+			attribute.String("net.peer.name", m.peer),
+		),
+	)
+	m.spansMu.Lock()
+	m.spans[evt.RequestID] = span
+	m.spansMu.Unlock()
+}
+
+// Succeeded implements plugport.Monitor.
+func (m *Monitor) Succeeded(ctx context.Context, evt plugport.CommandSucceededEvent) {
+	span := m.takeSpan(evt.RequestID)
+	if span == nil {
+		return
+	}
+	span.SetAttributes(attribute.Int("http.status_code", evt.StatusCode))
+	span.SetStatus(codes.Ok, "")
+	span.End()
+}
+
+// Failed implements plugport.Monitor.
+func (m *Monitor) Failed(ctx context.Context, evt plugport.CommandFailedEvent) {
+	span := m.takeSpan(evt.RequestID)
+	if span == nil {
+		return
+	}
+	span.SetAttributes(attribute.Int("http.status_code", evt.StatusCode))
+	span.SetStatus(codes.Error, evt.Failure)
+	span.End()
+}
+
+func (m *Monitor) takeSpan(requestID int64) trace.Span {
+	m.spansMu.Lock()
+	defer m.spansMu.Unlock()
+	span, ok := m.spans[requestID]
+	if !ok {
+		return nil
+	}
+	delete(m.spans, requestID)
+	return span
+}
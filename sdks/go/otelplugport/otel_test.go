@@ -0,0 +1,76 @@
+package otelplugport
+
+import (
+	"context"
+	"testing"
+
+	plugport "github.com/PlugPort-Protocol/plugport/sdks/go"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMonitorRecordsSpanForSucceededCommand(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	defer tp.Shutdown(context.Background())
+
+	mon := NewMonitor(tp, "plugport.example.com")
+
+	mon.Started(context.Background(), plugport.CommandStartedEvent{
+		RequestID:      1,
+		CommandName:    "find",
+		DatabaseName:   "testdb",
+		CollectionName: "users",
+	})
+	mon.Succeeded(context.Background(), plugport.CommandSucceededEvent{
+		RequestID:  1,
+		StatusCode: 200,
+	})
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name != "find" {
+		t.Fatalf("got span name %q, want find", spans[0].Name)
+	}
+}
+
+func TestMonitorRecordsSpanForFailedCommand(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	defer tp.Shutdown(context.Background())
+
+	mon := NewMonitor(tp, "plugport.example.com")
+
+	mon.Started(context.Background(), plugport.CommandStartedEvent{
+		RequestID:   2,
+		CommandName: "insertOne",
+	})
+	mon.Failed(context.Background(), plugport.CommandFailedEvent{
+		RequestID:  2,
+		StatusCode: 500,
+		Failure:    "boom",
+	})
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status.Description != "boom" {
+		t.Fatalf("got status description %q, want boom", spans[0].Status.Description)
+	}
+}
+
+func TestMonitorIgnoresUnknownRequestID(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	defer tp.Shutdown(context.Background())
+
+	mon := NewMonitor(tp, "plugport.example.com")
+	mon.Succeeded(context.Background(), plugport.CommandSucceededEvent{RequestID: 99})
+
+	if len(exporter.GetSpans()) != 0 {
+		t.Fatalf("expected no spans for an unknown request ID, got %d", len(exporter.GetSpans()))
+	}
+}
@@ -0,0 +1,60 @@
+package otelplugport
+
+import (
+	"context"
+
+	plugport "github.com/PlugPort-Protocol/plugport/sdks/go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMonitor implements plugport.Monitor by reporting per-operation
+// latency histograms and an in-flight gauge to Prometheus.
+type PrometheusMonitor struct {
+	latency  *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+	errors   *prometheus.CounterVec
+}
+
+// NewPrometheusMonitor builds a PrometheusMonitor and registers its
+// collectors with reg.
+func NewPrometheusMonitor(reg prometheus.Registerer) *PrometheusMonitor {
+	m := &PrometheusMonitor{
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "plugport",
+			Name:      "command_duration_seconds",
+			Help:      "Duration of PlugPort commands in seconds.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"command", "database", "collection"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "plugport",
+			Name:      "commands_in_flight",
+			Help:      "Number of PlugPort commands currently in flight.",
+		}, []string{"command", "database", "collection"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "plugport",
+			Name:      "command_errors_total",
+			Help:      "Total number of failed PlugPort commands.",
+		}, []string{"command", "database", "collection"}),
+	}
+
+	reg.MustRegister(m.latency, m.inFlight, m.errors)
+	return m
+}
+
+// Started implements plugport.Monitor.
+func (m *PrometheusMonitor) Started(ctx context.Context, evt plugport.CommandStartedEvent) {
+	m.inFlight.WithLabelValues(evt.CommandName, evt.DatabaseName, evt.CollectionName).Inc()
+}
+
+// Succeeded implements plugport.Monitor.
+func (m *PrometheusMonitor) Succeeded(ctx context.Context, evt plugport.CommandSucceededEvent) {
+	m.inFlight.WithLabelValues(evt.CommandName, evt.DatabaseName, evt.CollectionName).Dec()
+	m.latency.WithLabelValues(evt.CommandName, evt.DatabaseName, evt.CollectionName).Observe(evt.Duration.Seconds())
+}
+
+// Failed implements plugport.Monitor.
+func (m *PrometheusMonitor) Failed(ctx context.Context, evt plugport.CommandFailedEvent) {
+	m.inFlight.WithLabelValues(evt.CommandName, evt.DatabaseName, evt.CollectionName).Dec()
+	m.latency.WithLabelValues(evt.CommandName, evt.DatabaseName, evt.CollectionName).Observe(evt.Duration.Seconds())
+	m.errors.WithLabelValues(evt.CommandName, evt.DatabaseName, evt.CollectionName).Inc()
+}
@@ -0,0 +1,44 @@
+package otelplugport
+
+import (
+	"context"
+	"testing"
+
+	plugport "github.com/PlugPort-Protocol/plugport/sdks/go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusMonitorTracksInFlightAndLatency(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	mon := NewPrometheusMonitor(reg)
+
+	evt := plugport.CommandStartedEvent{CommandName: "find", DatabaseName: "testdb", CollectionName: "users"}
+	mon.Started(context.Background(), evt)
+
+	if got := testutil.ToFloat64(mon.inFlight.WithLabelValues("find", "testdb", "users")); got != 1 {
+		t.Fatalf("expected in-flight gauge of 1, got %v", got)
+	}
+
+	mon.Succeeded(context.Background(), plugport.CommandSucceededEvent{
+		CommandName:    "find",
+		DatabaseName:   "testdb",
+		CollectionName: "users",
+	})
+
+	if got := testutil.ToFloat64(mon.inFlight.WithLabelValues("find", "testdb", "users")); got != 0 {
+		t.Fatalf("expected in-flight gauge back to 0 after success, got %v", got)
+	}
+}
+
+func TestPrometheusMonitorCountsErrors(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	mon := NewPrometheusMonitor(reg)
+
+	mon.Started(context.Background(), plugport.CommandStartedEvent{CommandName: "insertOne"})
+	mon.Failed(context.Background(), plugport.CommandFailedEvent{CommandName: "insertOne"})
+
+	if got := testutil.ToFloat64(mon.errors.WithLabelValues("insertOne", "", "")); got != 1 {
+		t.Fatalf("expected 1 error counted, got %v", got)
+	}
+}
@@ -0,0 +1,70 @@
+// Package pipeline provides a fluent builder for MongoDB-style aggregation
+// pipelines. Pass a built pipeline's Stages() as the pipeline argument to
+// Collection.Aggregate.
+package pipeline
+
+// Pipeline is an ordered sequence of aggregation stages. Stage order is
+// preserved on marshal so pipelines round-trip deterministically.
+type Pipeline struct {
+	stages []map[string]interface{}
+}
+
+// New returns an empty Pipeline ready to be built up with fluent stage calls.
+func New() *Pipeline {
+	return &Pipeline{}
+}
+
+// Stages returns the underlying stage documents in order, suitable for
+// passing as the pipeline argument to Collection.Aggregate.
+func (p *Pipeline) Stages() []interface{} {
+	stages := make([]interface{}, len(p.stages))
+	for i, s := range p.stages {
+		stages[i] = s
+	}
+	return stages
+}
+
+func (p *Pipeline) add(op string, value interface{}) *Pipeline {
+	p.stages = append(p.stages, map[string]interface{}{op: value})
+	return p
+}
+
+// Match adds a $match stage.
+func (p *Pipeline) Match(filter interface{}) *Pipeline {
+	return p.add("$match", filter)
+}
+
+// Group adds a $group stage.
+func (p *Pipeline) Group(group interface{}) *Pipeline {
+	return p.add("$group", group)
+}
+
+// Sort adds a $sort stage.
+func (p *Pipeline) Sort(sort interface{}) *Pipeline {
+	return p.add("$sort", sort)
+}
+
+// Project adds a $project stage.
+func (p *Pipeline) Project(projection interface{}) *Pipeline {
+	return p.add("$project", projection)
+}
+
+// Lookup adds a $lookup stage.
+func (p *Pipeline) Lookup(lookup interface{}) *Pipeline {
+	return p.add("$lookup", lookup)
+}
+
+// Unwind adds an $unwind stage. path should include the leading "$", e.g. "$tags".
+func (p *Pipeline) Unwind(path string) *Pipeline {
+	return p.add("$unwind", path)
+}
+
+// Limit adds a $limit stage.
+func (p *Pipeline) Limit(n int64) *Pipeline {
+	return p.add("$limit", n)
+}
+
+// Skip adds a $skip stage.
+func (p *Pipeline) Skip(n int64) *Pipeline {
+	return p.add("$skip", n)
+}
@@ -24,24 +24,55 @@ package plugport
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sync/atomic"
 	"time"
 )
 
 // Client represents a PlugPort client connection.
 type Client struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
+	baseURL        string
+	apiKey         string
+	httpClient     *http.Client
+	codec          Codec
+	readConcern    *ReadConcern
+	writeConcern   *WriteConcern
+	readPreference *ReadPreference
+	retryWrites    bool
+	retryReads     bool
+	opCounter      uint64
+	monitor        Monitor
 }
 
 // ClientOptions contains options for creating a client.
 type ClientOptions struct {
 	APIKey  string
 	Timeout time.Duration
+	// Codec selects the wire encoding used for requests and responses.
+	// Defaults to CodecJSON.
+	Codec Codec
+	// ReadConcern, WriteConcern, and ReadPreference set the client-wide
+	// defaults; Database and Collection can override them with
+	// WithReadConcern/WithWriteConcern/WithReadPreference.
+	ReadConcern    *ReadConcern
+	WriteConcern   *WriteConcern
+	ReadPreference *ReadPreference
+	// RetryWrites transparently retries idempotent single-document writes
+	// once on a retryable error. Defaults to true.
+	RetryWrites *bool
+	// RetryReads transparently retries reads once on a retryable error.
+	// Defaults to true.
+	RetryReads *bool
+	// Monitor receives Started/Succeeded/Failed events for every command
+	// except change stream connections opened by Watch, e.g. to export
+	// metrics or tracing spans via the otelplugport package.
+	Monitor Monitor
+	// HTTPTransport overrides the pooled http.Transport used for all
+	// requests. When nil, a transport tuned for a single PlugPort
+	// connection is constructed automatically.
+	HTTPTransport *http.Transport
 }
 
 // Connect creates a new PlugPort client and verifies the connection.
@@ -53,12 +84,29 @@ func Connect(uri string, opts ...ClientOptions) (*Client, error) {
 	if opt.Timeout == 0 {
 		opt.Timeout = 30 * time.Second
 	}
+	if opt.Codec == "" {
+		opt.Codec = CodecJSON
+	}
+	retryWrites := opt.RetryWrites == nil || *opt.RetryWrites
+	retryReads := opt.RetryReads == nil || *opt.RetryReads
+	transport := opt.HTTPTransport
+	if transport == nil {
+		transport = newPooledTransport()
+	}
 
 	client := &Client{
-		baseURL: uri,
-		apiKey:  opt.APIKey,
+		baseURL:        uri,
+		apiKey:         opt.APIKey,
+		codec:          opt.Codec,
+		readConcern:    opt.ReadConcern,
+		writeConcern:   opt.WriteConcern,
+		readPreference: opt.ReadPreference,
+		retryWrites:    retryWrites,
+		retryReads:     retryReads,
+		monitor:        opt.Monitor,
 		httpClient: &http.Client{
-			Timeout: opt.Timeout,
+			Timeout:   opt.Timeout,
+			Transport: transport,
 		},
 	}
 
@@ -96,7 +144,13 @@ func (c *Client) doGet(ctx context.Context, path string) (map[string]interface{}
 }
 
 func (c *Client) doPost(ctx context.Context, path string, body interface{}) (map[string]interface{}, error) {
-	data, err := json.Marshal(body)
+	return c.doPostHeaders(ctx, path, body, nil)
+}
+
+// doPostHeaders is doPost with caller-supplied extra headers, used to attach
+// per-operation concerns like X-PlugPort-ReadPreference.
+func (c *Client) doPostHeaders(ctx context.Context, path string, body interface{}, extraHeaders map[string]string) (map[string]interface{}, error) {
+	data, err := c.codec.marshal(body)
 	if err != nil {
 		return nil, err
 	}
@@ -105,54 +159,148 @@ func (c *Client) doPost(ctx context.Context, path string, body interface{}) (map
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", c.codec.contentType())
+	req.Header.Set("Accept", c.codec.contentType())
+	for k, v := range extraHeaders {
+		req.Header.Set(k, v)
+	}
 	return c.doRequest(req)
 }
 
+// doRetryableWrite performs an idempotent single-document write, retrying
+// once on a retryable error with the same monotonic op ID so the server can
+// deduplicate, matching the mongo driver's retryable writes behavior.
+func (c *Client) doRetryableWrite(ctx context.Context, path string, body map[string]interface{}, extraHeaders map[string]string) (map[string]interface{}, error) {
+	if !c.retryWrites {
+		return c.doPostHeaders(ctx, path, body, extraHeaders)
+	}
+
+	headers := make(map[string]string, len(extraHeaders)+1)
+	for k, v := range extraHeaders {
+		headers[k] = v
+	}
+	headers["X-PlugPort-OpId"] = fmt.Sprint(atomic.AddUint64(&c.opCounter, 1))
+
+	result, err := c.doPostHeaders(ctx, path, body, headers)
+	if err != nil && isRetryableError(err) {
+		result, err = c.doPostHeaders(ctx, path, body, headers)
+	}
+	return result, err
+}
+
+func isRetryableError(err error) bool {
+	if ppErr, ok := err.(*PlugPortError); ok {
+		return ppErr.HasErrorLabel("RetryableWriteError")
+	}
+	// A non-PlugPortError is a transport failure (network error, timeout,
+	// connection reset) or a response that could not be read or parsed.
+	// Both are retried; the retry carries the same X-PlugPort-OpId so the
+	// server can deduplicate a write it already applied.
+	return true
+}
+
+// doRetryableRead performs an idempotent read, retrying once on a retryable
+// error, mirroring doRetryableWrite's behavior for reads. Reads don't need
+// an idempotency key: re-sending the same filter can't duplicate data.
+func (c *Client) doRetryableRead(ctx context.Context, path string, body map[string]interface{}, extraHeaders map[string]string) (map[string]interface{}, error) {
+	if !c.retryReads {
+		return c.doPostHeaders(ctx, path, body, extraHeaders)
+	}
+
+	result, err := c.doPostHeaders(ctx, path, body, extraHeaders)
+	if err != nil && isRetryableReadError(err) {
+		result, err = c.doPostHeaders(ctx, path, body, extraHeaders)
+	}
+	return result, err
+}
+
+func isRetryableReadError(err error) bool {
+	if ppErr, ok := err.(*PlugPortError); ok {
+		return ppErr.HasErrorLabel("RetryableReadError")
+	}
+	// A non-PlugPortError is a transport failure (network error, timeout,
+	// connection reset) or a response that could not be read or parsed.
+	// Both are retried.
+	return true
+}
+
 func (c *Client) doRequest(req *http.Request) (map[string]interface{}, error) {
 	if c.apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	}
+	applySessionHeaders(req)
+
+	ctx := req.Context()
+	path := req.URL.Path
+	requestID, start := c.monitorStart(ctx, path)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		c.monitorFail(ctx, path, requestID, start, 0, err.Error())
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
+		c.monitorFail(ctx, path, requestID, start, resp.StatusCode, err.Error())
 		return nil, err
 	}
 
 	var result map[string]interface{}
-	if err := json.Unmarshal(bodyBytes, &result); err != nil {
+	if err := c.codec.unmarshal(bodyBytes, &result); err != nil {
+		c.monitorFail(ctx, path, requestID, start, resp.StatusCode, err.Error())
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if resp.StatusCode >= 400 {
 		msg, _ := result["errmsg"].(string)
 		code, _ := result["code"].(float64)
-		return nil, &PlugPortError{Code: int(code), Message: msg}
+		var labels []string
+		if raw, ok := result["errorLabels"].([]interface{}); ok {
+			for _, l := range raw {
+				if s, ok := l.(string); ok {
+					labels = append(labels, s)
+				}
+			}
+		}
+		c.monitorFail(ctx, path, requestID, start, resp.StatusCode, msg)
+		return nil, &PlugPortError{Code: int(code), Message: msg, ErrorLabels: labels}
 	}
 
+	c.monitorSucceed(ctx, path, requestID, start, resp.StatusCode)
 	return result, nil
 }
 
 // PlugPortError represents an error from the PlugPort server.
 type PlugPortError struct {
-	Code    int
-	Message string
+	Code        int
+	Message     string
+	ErrorLabels []string
 }
 
 func (e *PlugPortError) Error() string {
 	return fmt.Sprintf("PlugPort error [%d]: %s", e.Code, e.Message)
 }
 
+// HasErrorLabel reports whether the server tagged this error with label,
+// e.g. "TransientTransactionError" or "UnknownTransactionCommitResult".
+func (e *PlugPortError) HasErrorLabel(label string) bool {
+	for _, l := range e.ErrorLabels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
 // Database represents a PlugPort database.
 type Database struct {
-	client *Client
-	name   string
+	client         *Client
+	name           string
+	readConcern    *ReadConcern
+	writeConcern   *WriteConcern
+	readPreference *ReadPreference
 }
 
 // Collection returns a collection handle.
@@ -185,14 +333,17 @@ func (d *Database) ListCollectionNames(ctx context.Context) ([]string, error) {
 
 // Collection represents a PlugPort collection.
 type Collection struct {
-	db   *Database
-	name string
+	db             *Database
+	name           string
+	readConcern    *ReadConcern
+	writeConcern   *WriteConcern
+	readPreference *ReadPreference
 }
 
 // InsertResult represents the result of an insert operation.
 type InsertResult struct {
-	Acknowledged bool
-	InsertedID   string
+	Acknowledged  bool
+	InsertedID    interface{}
 	InsertedCount int
 }
 
@@ -201,7 +352,7 @@ type UpdateResult struct {
 	Acknowledged  bool
 	MatchedCount  int
 	ModifiedCount int
-	UpsertedID    string
+	UpsertedID    interface{}
 }
 
 // DeleteResult represents the result of a delete operation.
@@ -212,25 +363,27 @@ type DeleteResult struct {
 
 // InsertOne inserts a single document.
 func (c *Collection) InsertOne(ctx context.Context, document interface{}) (*InsertResult, error) {
-	result, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/insertOne", c.name), map[string]interface{}{
+	body := c.applyWriteConcern(map[string]interface{}{
 		"document": document,
 	})
+	result, err := c.db.client.doRetryableWrite(ctx, fmt.Sprintf("/api/v1/collections/%s/insertOne", c.name), body, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	return &InsertResult{
-		Acknowledged: true,
-		InsertedID:   fmt.Sprint(result["insertedId"]),
+		Acknowledged:  true,
+		InsertedID:    result["insertedId"],
 		InsertedCount: 1,
 	}, nil
 }
 
 // InsertMany inserts multiple documents.
 func (c *Collection) InsertMany(ctx context.Context, documents []interface{}) (*InsertResult, error) {
-	result, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/insertMany", c.name), map[string]interface{}{
+	body := c.applyWriteConcern(map[string]interface{}{
 		"documents": documents,
 	})
+	result, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/insertMany", c.name), body)
 	if err != nil {
 		return nil, err
 	}
@@ -242,8 +395,30 @@ func (c *Collection) InsertMany(ctx context.Context, documents []interface{}) (*
 	}, nil
 }
 
-// Find returns documents matching the filter.
+// Find returns documents matching the filter. It fully drains the result
+// cursor, so for large result sets prefer FindCursor instead. Find is kept
+// for backward compatibility with the original slice-shaped API.
 func (c *Collection) Find(ctx context.Context, filter interface{}, opts ...FindOptions) ([]map[string]interface{}, error) {
+	cur, err := c.FindCursor(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	docs := make([]map[string]interface{}, 0)
+	for cur.Next(ctx) {
+		docs = append(docs, cur.current)
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// FindCursor returns documents matching the filter as a *Cursor, fetching
+// additional batches from the server lazily as the caller advances it. This
+// makes large result sets usable without holding everything in memory.
+func (c *Collection) FindCursor(ctx context.Context, filter interface{}, opts ...FindOptions) (*Cursor, error) {
 	body := map[string]interface{}{
 		"filter": filter,
 	}
@@ -260,46 +435,44 @@ func (c *Collection) Find(ctx context.Context, filter interface{}, opts ...FindO
 		if opts[0].Projection != nil {
 			body["projection"] = opts[0].Projection
 		}
+		if opts[0].BatchSize > 0 {
+			body["batchSize"] = opts[0].BatchSize
+		}
 	}
+	c.applyReadConcern(body)
 
-	result, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/find", c.name), body)
+	result, err := c.db.client.doRetryableRead(ctx, fmt.Sprintf("/api/v1/collections/%s/find", c.name), body, c.readPreferenceHeaders())
 	if err != nil {
 		return nil, err
 	}
 
-	cursor, ok := result["cursor"].(map[string]interface{})
-	if !ok {
-		return []map[string]interface{}{}, nil
-	}
-
-	batch, ok := cursor["firstBatch"].([]interface{})
-	if !ok {
-		return []map[string]interface{}{}, nil
+	cur, err := newCursor(c, result["cursor"])
+	if err != nil {
+		return nil, err
 	}
-
-	docs := make([]map[string]interface{}, 0, len(batch))
-	for _, d := range batch {
-		if m, ok := d.(map[string]interface{}); ok {
-			docs = append(docs, m)
-		}
+	if len(opts) > 0 {
+		cur.batchSize = opts[0].BatchSize
 	}
-	return docs, nil
+	return cur, nil
 }
 
-// FindOne returns a single document matching the filter.
-func (c *Collection) FindOne(ctx context.Context, filter interface{}) (map[string]interface{}, error) {
-	result, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/findOne", c.name), map[string]interface{}{
+// FindOne returns a SingleResult wrapping the document matching the filter,
+// if any. Errors are deferred until Decode is called, matching the
+// mongo-go-driver SingleResult ergonomics.
+func (c *Collection) FindOne(ctx context.Context, filter interface{}) *SingleResult {
+	body := c.applyReadConcern(map[string]interface{}{
 		"filter": filter,
 	})
+	result, err := c.db.client.doRetryableRead(ctx, fmt.Sprintf("/api/v1/collections/%s/findOne", c.name), body, c.readPreferenceHeaders())
 	if err != nil {
-		return nil, err
+		return &SingleResult{err: err}
 	}
 
 	doc, ok := result["document"].(map[string]interface{})
 	if !ok {
-		return nil, nil
+		return &SingleResult{err: ErrNoDocuments}
 	}
-	return doc, nil
+	return &SingleResult{doc: doc, codec: c.db.client.codec}
 }
 
 // UpdateOne updates a single document matching the filter.
@@ -311,8 +484,9 @@ func (c *Collection) UpdateOne(ctx context.Context, filter interface{}, update i
 	if len(opts) > 0 {
 		body["upsert"] = opts[0].Upsert
 	}
+	c.applyWriteConcern(body)
 
-	result, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/updateOne", c.name), body)
+	result, err := c.db.client.doRetryableWrite(ctx, fmt.Sprintf("/api/v1/collections/%s/updateOne", c.name), body, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -323,14 +497,16 @@ func (c *Collection) UpdateOne(ctx context.Context, filter interface{}, update i
 		Acknowledged:  true,
 		MatchedCount:  int(matched),
 		ModifiedCount: int(modified),
+		UpsertedID:    result["upsertedId"],
 	}, nil
 }
 
 // DeleteOne deletes a single document matching the filter.
 func (c *Collection) DeleteOne(ctx context.Context, filter interface{}) (*DeleteResult, error) {
-	result, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/deleteOne", c.name), map[string]interface{}{
+	body := c.applyWriteConcern(map[string]interface{}{
 		"filter": filter,
 	})
+	result, err := c.db.client.doRetryableWrite(ctx, fmt.Sprintf("/api/v1/collections/%s/deleteOne", c.name), body, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -344,9 +520,10 @@ func (c *Collection) DeleteOne(ctx context.Context, filter interface{}) (*Delete
 
 // DeleteMany deletes all documents matching the filter.
 func (c *Collection) DeleteMany(ctx context.Context, filter interface{}) (*DeleteResult, error) {
-	result, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/deleteMany", c.name), map[string]interface{}{
+	body := c.applyWriteConcern(map[string]interface{}{
 		"filter": filter,
 	})
+	result, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/deleteMany", c.name), body)
 	if err != nil {
 		return nil, err
 	}
@@ -358,20 +535,6 @@ func (c *Collection) DeleteMany(ctx context.Context, filter interface{}) (*Delet
 	}, nil
 }
 
-// CreateIndex creates an index on a field.
-func (c *Collection) CreateIndex(ctx context.Context, field string, unique bool) (string, error) {
-	result, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/createIndex", c.name), map[string]interface{}{
-		"field":  field,
-		"unique": unique,
-	})
-	if err != nil {
-		return "", err
-	}
-
-	name, _ := result["indexName"].(string)
-	return name, nil
-}
-
 // Drop drops this collection.
 func (c *Collection) Drop(ctx context.Context) error {
 	_, err := c.db.client.doPost(ctx, fmt.Sprintf("/api/v1/collections/%s/drop", c.name), map[string]interface{}{})
@@ -384,6 +547,9 @@ type FindOptions struct {
 	Skip       int
 	Sort       map[string]int
 	Projection map[string]int
+	// BatchSize sets the number of documents returned per batch, including
+	// subsequent server round-trips made while iterating a *Cursor.
+	BatchSize int32
 }
 
 // UpdateOptions contains options for Update operations.
@@ -0,0 +1,119 @@
+package plugport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDoRetryableReadRetriesOnceOnRetryableError(t *testing.T) {
+	var attempts int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok"})
+			return
+		}
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"errmsg":      "no primary available",
+				"code":        float64(10107),
+				"errorLabels": []interface{}{"RetryableReadError"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": float64(1)})
+	}))
+	defer srv.Close()
+
+	client, err := Connect(srv.URL)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer client.Close()
+
+	result, err := client.doRetryableRead(context.Background(), "/api/v1/collections/users/find", map[string]interface{}{}, nil)
+	if err != nil {
+		t.Fatalf("doRetryableRead: %v", err)
+	}
+	if result["ok"] != float64(1) {
+		t.Fatalf("got %+v", result)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestDoRetryableReadDoesNotRetryWhenDisabled(t *testing.T) {
+	var attempts int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok"})
+			return
+		}
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"errmsg":      "no primary available",
+			"code":        float64(10107),
+			"errorLabels": []interface{}{"RetryableReadError"},
+		})
+	}))
+	defer srv.Close()
+
+	noRetry := false
+	client, err := Connect(srv.URL, ClientOptions{RetryReads: &noRetry})
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.doRetryableRead(context.Background(), "/api/v1/collections/users/find", map[string]interface{}{}, nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected 1 attempt with retries disabled, got %d", attempts)
+	}
+}
+
+func TestDoRetryableWriteRetriesOnceOnRetryableError(t *testing.T) {
+	var attempts int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok"})
+			return
+		}
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"errmsg":      "not writable primary",
+				"code":        float64(10107),
+				"errorLabels": []interface{}{"RetryableWriteError"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"insertedId": "id-1"})
+	}))
+	defer srv.Close()
+
+	client, err := Connect(srv.URL)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer client.Close()
+
+	coll := client.Database("testdb").Collection("users")
+	if _, err := coll.InsertOne(context.Background(), map[string]interface{}{"x": 1}); err != nil {
+		t.Fatalf("InsertOne: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+}
@@ -0,0 +1,189 @@
+package plugport
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+const transactionRetryTimeLimit = 120 * time.Second
+
+// SessionOptions contains options for starting a Session.
+type SessionOptions struct {
+	// CausalConsistency mirrors the mongo driver's session option.
+	// StartSession does not read it: no causal-consistency setting is sent
+	// to the server, and sessions behave the same whatever its value.
+	CausalConsistency *bool
+}
+
+// TransactionOptions contains options for starting a transaction.
+type TransactionOptions struct {
+	// MaxCommitTimeMS mirrors the mongo driver's commit time limit.
+	// StartTransaction and WithTransaction do not read it, and
+	// CommitTransaction sends no commit time limit to the server.
+	MaxCommitTimeMS int64
+}
+
+// Session represents a server-side session and, optionally, an in-progress
+// transaction. Obtain one with Client.StartSession and thread it through
+// Collection/Database calls via a SessionContext.
+type Session struct {
+	client        *Client
+	id            string
+	txnNumber     int64
+	inTransaction bool
+}
+
+// SessionContext carries a Session alongside a context.Context so that
+// existing Collection and Database methods pick up the session's headers
+// transparently. The session is attached via context.WithValue, so it
+// survives being wrapped by standard combinators like
+// context.WithTimeout(sc, ...), unlike a type assertion back to
+// SessionContext would.
+type SessionContext struct {
+	context.Context
+	Session *Session
+}
+
+// sessionContextKey is the context.Value key under which a session is
+// stored, matching the mongo driver's approach of keying session context
+// off a value rather than a concrete context type.
+type sessionContextKey struct{}
+
+// newSessionContext returns a SessionContext for s, rooted in ctx.
+func newSessionContext(ctx context.Context, s *Session) SessionContext {
+	return SessionContext{Context: context.WithValue(ctx, sessionContextKey{}, s), Session: s}
+}
+
+// sessionFromContext returns the Session attached to ctx, or nil if none.
+func sessionFromContext(ctx context.Context) *Session {
+	s, _ := ctx.Value(sessionContextKey{}).(*Session)
+	return s
+}
+
+// StartSession opens a new server-side session.
+func (c *Client) StartSession(opts ...SessionOptions) (*Session, error) {
+	result, err := c.doPost(context.Background(), "/api/v1/sessions", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	id, _ := result["sessionId"].(string)
+	if id == "" {
+		return nil, fmt.Errorf("plugport: server did not return a sessionId")
+	}
+
+	return &Session{client: c, id: id}, nil
+}
+
+// StartTransaction marks the session as having an in-progress transaction
+// and advances its transaction number.
+func (s *Session) StartTransaction(opts ...TransactionOptions) error {
+	if s.inTransaction {
+		return fmt.Errorf("plugport: transaction already in progress")
+	}
+	s.txnNumber++
+	s.inTransaction = true
+	return nil
+}
+
+// CommitTransaction commits the session's in-progress transaction.
+func (s *Session) CommitTransaction(ctx context.Context) error {
+	sc := newSessionContext(ctx, s)
+	_, err := s.client.doPost(sc, fmt.Sprintf("/api/v1/sessions/%s/commitTransaction", s.id), map[string]interface{}{
+		"txnNumber": s.txnNumber,
+	})
+	if err == nil {
+		s.inTransaction = false
+	}
+	return err
+}
+
+// AbortTransaction aborts the session's in-progress transaction.
+func (s *Session) AbortTransaction(ctx context.Context) error {
+	sc := newSessionContext(ctx, s)
+	_, err := s.client.doPost(sc, fmt.Sprintf("/api/v1/sessions/%s/abortTransaction", s.id), map[string]interface{}{
+		"txnNumber": s.txnNumber,
+	})
+	s.inTransaction = false
+	return err
+}
+
+// EndSession releases the server-side session.
+func (s *Session) EndSession(ctx context.Context) error {
+	_, err := s.client.doPost(ctx, fmt.Sprintf("/api/v1/sessions/%s/endSession", s.id), map[string]interface{}{})
+	return err
+}
+
+// WithTransaction runs fn inside a transaction, committing on success and
+// aborting on error. Transient errors (PlugPortError tagged with
+// TransientTransactionError or UnknownTransactionCommitResult) are retried
+// with exponential backoff for up to 120 seconds, matching the mongo driver.
+func (s *Session) WithTransaction(ctx context.Context, fn func(sc SessionContext) (interface{}, error), opts ...TransactionOptions) (interface{}, error) {
+	deadline := time.Now().Add(transactionRetryTimeLimit)
+	backoff := 10 * time.Millisecond
+
+	for {
+		if err := s.StartTransaction(opts...); err != nil {
+			return nil, err
+		}
+
+		sc := newSessionContext(ctx, s)
+		result, err := fn(sc)
+		if err != nil {
+			_ = s.AbortTransaction(ctx)
+			if isTransientTransactionError(err) && time.Now().Before(deadline) {
+				time.Sleep(jitter(backoff))
+				backoff *= 2
+				continue
+			}
+			return nil, err
+		}
+
+		commitErr := s.CommitTransaction(ctx)
+		for commitErr != nil && isUnknownCommitResult(commitErr) && time.Now().Before(deadline) {
+			time.Sleep(jitter(backoff))
+			backoff *= 2
+			commitErr = s.CommitTransaction(ctx)
+		}
+		if commitErr != nil {
+			if isTransientTransactionError(commitErr) && time.Now().Before(deadline) {
+				s.inTransaction = false
+				time.Sleep(jitter(backoff))
+				backoff *= 2
+				continue
+			}
+			return nil, commitErr
+		}
+
+		return result, nil
+	}
+}
+
+func isTransientTransactionError(err error) bool {
+	ppErr, ok := err.(*PlugPortError)
+	return ok && ppErr.HasErrorLabel("TransientTransactionError")
+}
+
+func isUnknownCommitResult(err error) bool {
+	ppErr, ok := err.(*PlugPortError)
+	return ok && ppErr.HasErrorLabel("UnknownTransactionCommitResult")
+}
+
+func jitter(d time.Duration) time.Duration {
+	return d/2 + time.Duration(rand.Int63n(int64(d)))
+}
+
+// applySessionHeaders attaches session identification to outgoing requests
+// when the request's context carries a session, however deeply it's been
+// wrapped by standard context combinators.
+func applySessionHeaders(req *http.Request) {
+	s := sessionFromContext(req.Context())
+	if s == nil {
+		return
+	}
+	req.Header.Set("X-PlugPort-Session", s.id)
+	req.Header.Set("X-PlugPort-TxnNumber", fmt.Sprint(s.txnNumber))
+}
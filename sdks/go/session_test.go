@@ -0,0 +1,112 @@
+package plugport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithTransactionRetriesCommitOnUnknownResult(t *testing.T) {
+	var commitAttempts int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/health":
+			json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok"})
+		case r.URL.Path == "/api/v1/sessions":
+			json.NewEncoder(w).Encode(map[string]interface{}{"sessionId": "sess-1"})
+		case r.URL.Path == "/api/v1/sessions/sess-1/commitTransaction":
+			commitAttempts++
+			if commitAttempts == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"errmsg":      "commit result unknown",
+					"code":        float64(50),
+					"errorLabels": []interface{}{"UnknownTransactionCommitResult"},
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+		}
+	}))
+	defer srv.Close()
+
+	client, err := Connect(srv.URL)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer client.Close()
+
+	sess, err := client.StartSession()
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+
+	result, err := sess.WithTransaction(context.Background(), func(sc SessionContext) (interface{}, error) {
+		return "ok", nil
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction: %v", err)
+	}
+	if result != "ok" {
+		t.Fatalf("got result %v", result)
+	}
+	if commitAttempts != 2 {
+		t.Fatalf("expected 2 commit attempts, got %d", commitAttempts)
+	}
+}
+
+func TestSessionHeadersSurviveWrappedContext(t *testing.T) {
+	var gotSession, gotTxnNumber string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok"})
+		case "/api/v1/sessions":
+			json.NewEncoder(w).Encode(map[string]interface{}{"sessionId": "sess-1"})
+		case "/api/v1/collections/users/insertOne":
+			gotSession = r.Header.Get("X-PlugPort-Session")
+			gotTxnNumber = r.Header.Get("X-PlugPort-TxnNumber")
+			json.NewEncoder(w).Encode(map[string]interface{}{"insertedId": "id-1"})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+		}
+	}))
+	defer srv.Close()
+
+	client, err := Connect(srv.URL)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer client.Close()
+
+	sess, err := client.StartSession()
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	sess.txnNumber = 7
+
+	sc := newSessionContext(context.Background(), sess)
+	// A caller wrapping a SessionContext with a standard combinator, e.g. a
+	// per-call timeout, must not lose the session headers.
+	ctx, cancel := context.WithTimeout(sc, time.Second)
+	defer cancel()
+
+	coll := client.Database("testdb").Collection("users")
+	if _, err := coll.InsertOne(ctx, map[string]interface{}{"x": 1}); err != nil {
+		t.Fatalf("InsertOne: %v", err)
+	}
+
+	if gotSession != "sess-1" {
+		t.Fatalf("expected X-PlugPort-Session sess-1, got %q", gotSession)
+	}
+	if gotTxnNumber != "7" {
+		t.Fatalf("expected X-PlugPort-TxnNumber 7, got %q", gotTxnNumber)
+	}
+}
@@ -0,0 +1,31 @@
+package plugport
+
+import "errors"
+
+// ErrNoDocuments is returned by SingleResult.Decode when the operation that
+// produced the SingleResult found no matching document.
+var ErrNoDocuments = errors.New("plugport: no documents in result")
+
+// SingleResult represents a single document result from an operation such
+// as FindOne, deferring any error until Decode is called.
+type SingleResult struct {
+	doc   map[string]interface{}
+	err   error
+	codec Codec
+}
+
+// Decode unmarshals the result document into v. If the operation that
+// produced the SingleResult failed or found no document, Decode returns
+// that error without touching v.
+func (r *SingleResult) Decode(v interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return decodeDocument(r.codec, r.doc, v)
+}
+
+// Err returns the error, if any, associated with the result, without
+// decoding a document.
+func (r *SingleResult) Err() error {
+	return r.err
+}
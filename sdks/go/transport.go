@@ -0,0 +1,29 @@
+package plugport
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// newPooledTransport builds an http.Transport tuned for a client that talks
+// to a single PlugPort deployment repeatedly, reusing connections instead of
+// dialing fresh TCP (and TLS) handshakes per request.
+func newPooledTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   32,
+		MaxConnsPerHost:       64,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
@@ -0,0 +1,20 @@
+package plugport
+
+import "testing"
+
+func TestNewPooledTransportTunedForSingleHost(t *testing.T) {
+	transport := newPooledTransport()
+
+	if transport.MaxIdleConnsPerHost != 32 {
+		t.Fatalf("got MaxIdleConnsPerHost %d, want 32", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 64 {
+		t.Fatalf("got MaxConnsPerHost %d, want 64", transport.MaxConnsPerHost)
+	}
+	if !transport.ForceAttemptHTTP2 {
+		t.Fatal("expected ForceAttemptHTTP2 to be true")
+	}
+	if transport.DialContext == nil {
+		t.Fatal("expected DialContext to be set")
+	}
+}